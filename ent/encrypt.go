@@ -0,0 +1,49 @@
+package ent
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ParseRSAPrivateKeyFromString 解析 PEM 格式的 RSA 私钥, 支持 PKCS1 与 PKCS8 格式
+func ParseRSAPrivateKeyFromString(pemStr string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemStr))
+	if block == nil {
+		return nil, errors.New("parse rsa private key: invalid pem")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse rsa private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parse rsa private key: unexpected key type %T", key)
+	}
+	return rsaKey, nil
+}
+
+// ParseRSAPublicKeyFromString 解析 PEM 格式的 RSA 公钥, 支持 PKIX 与 PKCS1 格式
+func ParseRSAPublicKeyFromString(pemStr string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemStr))
+	if block == nil {
+		return nil, errors.New("parse rsa public key: invalid pem")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		if rsaKey, pkcs1Err := x509.ParsePKCS1PublicKey(block.Bytes); pkcs1Err == nil {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("parse rsa public key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("parse rsa public key: unexpected key type %T", key)
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,44 @@
+package ent
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRSAKeyFromString(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	privateKeys := map[string]string{
+		"PKCS1": string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"PKCS8": string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+	}
+	for name, pemStr := range privateKeys {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := ParseRSAPrivateKeyFromString(pemStr)
+			require.NoError(t, err)
+			assert.True(t, key.Equal(parsed))
+		})
+	}
+
+	publicKey, err := ParseRSAPublicKeyFromString(
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+
+	_, err = ParseRSAPrivateKeyFromString("invalid")
+	assert.Error(t, err)
+	_, err = ParseRSAPublicKeyFromString("invalid")
+	assert.Error(t, err)
+}
@@ -3,6 +3,8 @@ package errors
 import (
 	"context"
 	"net/http"
+
+	"google.golang.org/grpc"
 )
 
 // HttpServerErrorEncoder is a server error encoder.
@@ -35,3 +37,15 @@ func RPCClientErrorParser(handler RPCHandler) RPCHandler {
 		return reply, nil
 	}
 }
+
+// UnaryClientInterceptor returns a grpc client interceptor that converts errors to *Error.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return FromError(err)
+		}
+		return nil
+	}
+}
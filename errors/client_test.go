@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		opts ...grpc.CallOption,
+	) error {
+		return status.Error(codes.NotFound, "missing")
+	}
+	err := interceptor(context.Background(), "/test.Svc/Get", nil, nil, nil, invoker)
+	se, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if se.Status != 404 || se.Message != "missing" {
+		t.Fatalf("unexpected error: %v", se)
+	}
+
+	success := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		opts ...grpc.CallOption,
+	) error {
+		return nil
+	}
+	if err = interceptor(context.Background(), "/test.Svc/Get", nil, nil, nil, success); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
@@ -19,6 +22,16 @@ type PBError = pberrors.Error
 
 type Error PBError
 
+// FromProto converts a proto error to *Error, the returned error shares memory with pb.
+func FromProto(pb *PBError) *Error {
+	return (*Error)(pb)
+}
+
+// ToProto converts se to a proto error, the returned error shares memory with se.
+func (e *Error) ToProto() *PBError {
+	return (*PBError)(e)
+}
+
 // Error return text message and http status code.
 func (e *Error) Error() string {
 	return fmt.Sprintf("error: code = %d reason = %s message = %s", e.Status, e.Info.Reason, e.Message)
@@ -33,13 +46,25 @@ func (e *Error) Is(err error) bool {
 }
 
 // GRPCStatus returns the Status represented by se.
+// the http status code is kept in MetadataKeyHTTPStatus when the grpc code does not convert back to it.
 func (e *Error) GRPCStatus() *status.Status {
 	s := &spb.Status{Code: int32(ToGRPCCode(int(e.Status))), Message: e.Message}
 	if codes.Code(s.Code) == codes.OK {
 		return status.FromProto(s)
 	}
+	errInfo := e.Info
+	if FromGRPCCode(codes.Code(s.Code)) != int(e.Status) {
+		errInfo = &errdetails.ErrorInfo{}
+		if e.Info != nil {
+			errInfo = proto.Clone(e.Info).(*errdetails.ErrorInfo)
+		}
+		if errInfo.Metadata == nil {
+			errInfo.Metadata = make(map[string]string, 1)
+		}
+		errInfo.Metadata[MetadataKeyHTTPStatus] = strconv.Itoa(int(e.Status))
+	}
 	s.Details = make([]*anypb.Any, 0, len(e.Details)+1)
-	info, _ := anypb.New(e.Info)
+	info, _ := anypb.New(errInfo)
 	s.Details = append(s.Details, info)
 	for _, detail := range e.Details {
 		s.Details = append(s.Details, detail)
@@ -53,6 +78,35 @@ func (e *Error) MarshalJSON() ([]byte, error) {
 	return protojson.Marshal(pbErr)
 }
 
+const (
+	// RedactMessageMaxLength is the max rune length of message kept by Redact.
+	RedactMessageMaxLength = 256
+	// RedactMask is the mask that replaces metadata values in Redact.
+	RedactMask = "***"
+)
+
+// Redact returns the JSON representation of se with metadata values masked
+// and the message truncated to RedactMessageMaxLength runes.
+func (e *Error) Redact() string {
+	if e == nil {
+		return ""
+	}
+	redacted := e.Clone()
+	if message := []rune(redacted.Message); len(message) > RedactMessageMaxLength {
+		redacted.Message = string(message[:RedactMessageMaxLength])
+	}
+	if redacted.Info != nil {
+		for key := range redacted.Info.Metadata {
+			redacted.Info.Metadata[key] = RedactMask
+		}
+	}
+	data, err := redacted.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // Clone returns a deep copy of se.
 func (e *Error) Clone() *Error {
 	if e == nil {
@@ -66,16 +120,86 @@ func (e *Error) Clone() *Error {
 // SetMetadata set metadata for error info.
 func (e *Error) SetMetadata(key, value string) *Error {
 	copied := e.Clone()
+	if copied.Info == nil {
+		copied.Info = &errdetails.ErrorInfo{}
+	}
+	if copied.Info.Metadata == nil {
+		copied.Info.Metadata = make(map[string]string)
+	}
 	copied.Info.Metadata[key] = value
 	return copied
 }
 
+// MetadataKeyCause is the error info metadata key of cause.
+const MetadataKeyCause = "cause"
+
 // SetCause set cause for error info.
 func (e *Error) SetCause(err error) *Error {
 	if err == nil {
 		return e
 	}
-	return e.SetMetadata("cause", err.Error())
+	return e.SetMetadata(MetadataKeyCause, err.Error())
+}
+
+// Wrap returns an error object for the code, message and error info with err as its cause.
+// It returns nil when err is nil.
+func Wrap(err error, code int, reason, message string) *Error {
+	if err == nil {
+		return nil
+	}
+	return New(code, reason, message).SetCause(err)
+}
+
+// Cause returns the cause of an error set by SetCause or Wrap as a plain error.
+// It supports wrapped errors.
+func Cause(err error) error {
+	se := FromError(err)
+	if se == nil || se.Info == nil {
+		return nil
+	}
+	if cause := se.Info.Metadata[MetadataKeyCause]; cause != "" {
+		return errors.New(cause)
+	}
+	return nil
+}
+
+// MetadataKeyTraceID is the error info metadata key of trace id.
+const MetadataKeyTraceID = "trace_id"
+
+// WithTraceID set the trace id of the active span in ctx for error info.
+// it returns se unchanged when ctx has no active span.
+func (e *Error) WithTraceID(ctx context.Context) *Error {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.HasTraceID() {
+		return e
+	}
+	return e.SetMetadata(MetadataKeyTraceID, spanContext.TraceID().String())
+}
+
+// TraceID returns the trace id of an error set by WithTraceID.
+// It supports wrapped errors.
+func TraceID(err error) string {
+	se := FromError(err)
+	if se == nil || se.Info == nil {
+		return ""
+	}
+	return se.Info.Metadata[MetadataKeyTraceID]
+}
+
+// WithRequestID set the request id for error info.
+func (e *Error) WithRequestID(id string) *Error {
+	return e.SetMetadata(MetadataKeyRequestID, id)
+}
+
+// RequestID returns the request id of an error set by WithRequestID.
+// It supports wrapped errors.
+func RequestID(err error) (string, bool) {
+	se := FromError(err)
+	if se == nil || se.Info == nil {
+		return "", false
+	}
+	id, ok := se.Info.Metadata[MetadataKeyRequestID]
+	return id, ok && id != ""
 }
 
 // SetDomainAndCode set domain and code for info without clone.
@@ -163,6 +287,12 @@ func FromError(err error) *Error {
 				for k, v := range info.Metadata {
 					ret.Info.Metadata[k] = v
 				}
+				if v, ok := ret.Info.Metadata[MetadataKeyHTTPStatus]; ok {
+					if code, err := strconv.Atoi(v); err == nil && ToGRPCCode(code) == gs.Code() {
+						ret.Status = int32(code)
+					}
+					delete(ret.Info.Metadata, MetadataKeyHTTPStatus)
+				}
 				ret.Details = ret.Details[1:]
 			}
 		}
@@ -247,6 +377,41 @@ func IsServiceUnavailable(err error) bool {
 	return Code(err) == 503
 }
 
+// RequestTimeout new RequestTimeout error that is mapped to an HTTP 408 response.
+func RequestTimeout(reason, message string) *Error {
+	return New(408, reason, message)
+}
+
+// IsRequestTimeout determines if err is an error which indicates a RequestTimeout error.
+// It supports wrapped errors.
+func IsRequestTimeout(err error) bool {
+	return Code(err) == 408
+}
+
+// RequestEntityTooLarge new RequestEntityTooLarge error that is mapped to an HTTP 413 response.
+func RequestEntityTooLarge(reason, message string) *Error {
+	return New(413, reason, message)
+}
+
+// IsRequestEntityTooLarge determines if err is an error which indicates a RequestEntityTooLarge error.
+// It supports wrapped errors.
+func IsRequestEntityTooLarge(err error) bool {
+	return Code(err) == 413
+}
+
+// BadGateway new BadGateway error that is mapped to an HTTP 502 response.
+// unlike GatewayTimeout it means the upstream returned an invalid response.
+// it is sent over grpc as Unavailable and converted back to 502 by FromError.
+func BadGateway(reason, message string) *Error {
+	return New(502, reason, message)
+}
+
+// IsBadGateway determines if err is an error which indicates a BadGateway error.
+// It supports wrapped errors.
+func IsBadGateway(err error) bool {
+	return Code(err) == 502
+}
+
 // GatewayTimeout new GatewayTimeout error that is mapped to an HTTP 504 response.
 func GatewayTimeout(reason, message string) *Error {
 	return New(504, reason, message)
@@ -258,6 +423,25 @@ func IsGatewayTimeout(err error) bool {
 	return Code(err) == 504
 }
 
+// IsTemporary determines if err is a TooManyRequests, BadGateway, ServiceUnavailable
+// or GatewayTimeout error which is worth retrying.
+// It supports wrapped errors.
+func IsTemporary(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch Code(err) {
+	case 429, 502, 503, 504:
+		return true
+	}
+	return false
+}
+
+// IsRetryable is an alias of IsTemporary.
+func IsRetryable(err error) bool {
+	return IsTemporary(err)
+}
+
 // ClientClosed new ClientClosed error that is mapped to an HTTP 499 response.
 func ClientClosed(reason, message string) *Error {
 	return New(499, reason, message)
@@ -1,10 +1,15 @@
 package errors
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 )
 
 func TestError_Clone(t *testing.T) {
@@ -54,3 +59,130 @@ func TestError_Clone(t *testing.T) {
 		})
 	}
 }
+
+func TestError_Redact(t *testing.T) {
+	err := New(400, "bad", strings.Repeat("x", RedactMessageMaxLength+10)).
+		SetDomainAndCode("test", 1).SetMetadata("token", "secret")
+	redacted := err.Redact()
+	if strings.Contains(redacted, "secret") {
+		t.Fatalf("metadata value is not masked: %s", redacted)
+	}
+	if !strings.Contains(redacted, `"token":"***"`) {
+		t.Fatalf("metadata key is not kept: %s", redacted)
+	}
+	if strings.Contains(redacted, strings.Repeat("x", RedactMessageMaxLength+1)) {
+		t.Fatalf("message is not truncated: %s", redacted)
+	}
+	if err.Info.Metadata["token"] != "secret" {
+		t.Fatal("Redact must not modify the original error")
+	}
+}
+
+func TestError_WithTraceID(t *testing.T) {
+	err := BadRequest("bad", "bad request")
+	if got := err.WithTraceID(context.Background()); got != err {
+		t.Fatal("WithTraceID without span should return the error unchanged")
+	}
+
+	traceID := trace.TraceID{1, 2, 3}
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	if got := TraceID(fmt.Errorf("wrapped: %w", err.WithTraceID(ctx))); got != traceID.String() {
+		t.Fatalf("expected trace id %s, got %s", traceID, got)
+	}
+	if TraceID(err) != "" {
+		t.Fatal("WithTraceID must not modify the original error")
+	}
+}
+
+func TestError_WithRequestID(t *testing.T) {
+	err := NotFound("missing", "not found")
+	if _, ok := RequestID(err); ok {
+		t.Fatal("expected no request id")
+	}
+	if id, ok := RequestID(fmt.Errorf("wrapped: %w", err.WithRequestID("req-1"))); !ok || id != "req-1" {
+		t.Fatalf("expected request id req-1, got %q", id)
+	}
+	if _, ok := RequestID(nil); ok {
+		t.Fatal("expected no request id for nil error")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if Wrap(nil, 500, "internal", "internal error") != nil {
+		t.Fatal("Wrap(nil) should return nil")
+	}
+	err := Wrap(fmt.Errorf("connection refused"), 503, "unavailable", "service unavailable")
+	if err.Status != 503 || err.Info.Reason != "unavailable" {
+		t.Fatalf("unexpected wrapped error: %v", err)
+	}
+	if cause := Cause(fmt.Errorf("wrapped: %w", err)); cause == nil || cause.Error() != "connection refused" {
+		t.Fatalf("unexpected cause: %v", cause)
+	}
+	if Cause(BadRequest("bad", "bad request")) != nil {
+		t.Fatal("expected no cause")
+	}
+}
+
+func TestBadGateway(t *testing.T) {
+	err := BadGateway("upstream", "invalid upstream response")
+	if !IsBadGateway(fmt.Errorf("wrapped: %w", err)) || IsGatewayTimeout(err) {
+		t.Fatal("unexpected BadGateway classification")
+	}
+	if code := ToGRPCCode(502); code != codes.Unavailable {
+		t.Fatalf("expected 502 to map to Unavailable, got %v", code)
+	}
+
+	got := FromError(err.SetMetadata("key", "value").GRPCStatus().Err())
+	if !IsBadGateway(got) || got.Info.Reason != "upstream" || got.Info.Metadata["key"] != "value" {
+		t.Fatalf("expected BadGateway to survive grpc, got %v %v", got, got.Info.Metadata)
+	}
+	if _, ok := got.Info.Metadata[MetadataKeyHTTPStatus]; ok {
+		t.Fatalf("unexpected http status metadata: %v", got.Info.Metadata)
+	}
+	if _, ok := err.Info.Metadata[MetadataKeyHTTPStatus]; ok {
+		t.Fatal("GRPCStatus must not modify the error metadata")
+	}
+	got = FromError(ServiceUnavailable("down", "service unavailable").GRPCStatus().Err())
+	if !IsServiceUnavailable(got) || len(got.Info.Metadata) != 0 {
+		t.Fatalf("expected ServiceUnavailable without http status metadata, got %v %v", got, got.Info.Metadata)
+	}
+}
+
+func TestRequestTimeoutAndEntityTooLarge(t *testing.T) {
+	timeout := RequestTimeout("timeout", "request timeout")
+	tooLarge := RequestEntityTooLarge("too_large", "request entity too large")
+	if !IsRequestTimeout(timeout) || IsRequestTimeout(tooLarge) {
+		t.Fatal("unexpected RequestTimeout classification")
+	}
+	if !IsRequestEntityTooLarge(fmt.Errorf("wrapped: %w", tooLarge)) || IsRequestEntityTooLarge(timeout) {
+		t.Fatal("unexpected RequestEntityTooLarge classification")
+	}
+	if code := ToGRPCCode(408); code != codes.DeadlineExceeded {
+		t.Fatalf("expected 408 to map to DeadlineExceeded, got %v", code)
+	}
+	if code := ToGRPCCode(413); code != codes.ResourceExhausted {
+		t.Fatalf("expected 413 to map to ResourceExhausted, got %v", code)
+	}
+}
+
+func TestIsTemporary(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{New(429, "limited", "too many requests"), true},
+		{BadGateway("upstream", "bad gateway"), true},
+		{fmt.Errorf("wrapped: %w", ServiceUnavailable("down", "service unavailable")), true},
+		{GatewayTimeout("timeout", "gateway timeout"), true},
+		{BadRequest("bad", "bad request"), false},
+		{InternalServer("internal", "internal error"), false},
+		{fmt.Errorf("plain error"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsTemporary(tt.err); got != tt.want || IsRetryable(tt.err) != tt.want {
+			t.Fatalf("IsTemporary(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
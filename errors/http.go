@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"net/http"
+)
+
+const (
+	// MetadataKeyRequestID is the error info metadata key of request id.
+	MetadataKeyRequestID = "request_id"
+	// HeaderRequestID is the http header of request id.
+	HeaderRequestID = "X-Request-Id"
+)
+
+// WriteErrorResponse writes se as JSON response with its http status code.
+// the X-Request-Id header is set when the error metadata carries a request id.
+// a nil se or a status outside 100-599 is written with status 500.
+func WriteErrorResponse(w http.ResponseWriter, se *Error) {
+	if se == nil {
+		se = New(UnknownCode, UnknownReason, http.StatusText(http.StatusInternalServerError))
+	}
+	code := int(se.Status)
+	if code < 100 || code > 599 {
+		code = http.StatusInternalServerError
+	}
+	body, err := se.MarshalJSON()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if se.Info != nil {
+		if id := se.Info.Metadata[MetadataKeyRequestID]; id != "" {
+			w.Header().Set(HeaderRequestID, id)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// WriteBadRequestResponse writes a BadRequest error response.
+func WriteBadRequestResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, BadRequest(reason, message))
+}
+
+// WriteUnauthorizedResponse writes an Unauthorized error response.
+func WriteUnauthorizedResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, Unauthorized(reason, message))
+}
+
+// WriteForbiddenResponse writes a Forbidden error response.
+func WriteForbiddenResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, Forbidden(reason, message))
+}
+
+// WriteNotFoundResponse writes a NotFound error response.
+func WriteNotFoundResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, NotFound(reason, message))
+}
+
+// WriteConflictResponse writes a Conflict error response.
+func WriteConflictResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, Conflict(reason, message))
+}
+
+// WriteInternalServerResponse writes an InternalServer error response.
+func WriteInternalServerResponse(w http.ResponseWriter, reason, message string) {
+	WriteErrorResponse(w, InternalServer(reason, message))
+}
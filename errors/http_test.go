@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteErrorResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := NotFound("USER_NOT_FOUND", "user not found").
+		SetDomainAndCode("test", 1).SetMetadata(MetadataKeyRequestID, "req-1")
+	WriteErrorResponse(recorder, err)
+
+	if recorder.Code != 404 {
+		t.Fatalf("expected status 404, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected content type: %s", recorder.Header().Get("Content-Type"))
+	}
+	if recorder.Header().Get(HeaderRequestID) != "req-1" {
+		t.Fatalf("unexpected request id: %s", recorder.Header().Get(HeaderRequestID))
+	}
+	if !strings.Contains(recorder.Body.String(), "USER_NOT_FOUND") {
+		t.Fatalf("unexpected body: %s", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	WriteNotFoundResponse(recorder, "USER_NOT_FOUND", "user not found")
+	if recorder.Code != 404 || recorder.Header().Get(HeaderRequestID) != "" {
+		t.Fatalf("unexpected response: %d %v", recorder.Code, recorder.Header())
+	}
+}
+
+func TestWriteErrorResponse_InvalidStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+	}{
+		{"nil", nil},
+		{"zero value", &Error{}},
+		{"below range", New(99, "", "")},
+		{"above range", New(600, "", "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			WriteErrorResponse(recorder, tt.err)
+			if recorder.Code != http.StatusInternalServerError {
+				t.Fatalf("expected status 500, got %d", recorder.Code)
+			}
+			if recorder.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("unexpected content type: %s", recorder.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
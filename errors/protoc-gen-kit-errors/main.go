@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/pluginpb"
 
 	pberrors "github.com/crypto-zero/go-kit/proto/kit/errors/v1"
@@ -42,6 +44,7 @@ func (g *GenerateErrorDeclare) Run() error {
 			continue
 		}
 		inputValues := make(map[*protogen.EnumValue]*pberrors.EnumErrorDetail)
+		codes := make(map[protoreflect.EnumNumber]*protogen.EnumValue)
 		for _, e := range f.Enums {
 			for _, ev := range e.Values {
 				extDetail := proto.GetExtension(ev.Desc.Options(), pberrors.E_ErrorDetail)
@@ -52,9 +55,13 @@ func (g *GenerateErrorDeclare) Run() error {
 				if !ok || detail == nil {
 					continue
 				}
-				if detail.Code > 0 {
-					inputValues[ev] = detail
+				if detail.Code <= 0 {
+					continue
+				}
+				if err := validateErrorCode(f, codes, ev); err != nil {
+					return err
 				}
+				inputValues[ev] = detail
 			}
 		}
 		if len(inputValues) == 0 {
@@ -67,6 +74,25 @@ func (g *GenerateErrorDeclare) Run() error {
 	return nil
 }
 
+// validateErrorCode checks the enum number used as error code is non-negative and unique in the file.
+func validateErrorCode(
+	f *protogen.File,
+	codes map[protoreflect.EnumNumber]*protogen.EnumValue,
+	ev *protogen.EnumValue,
+) error {
+	number := ev.Desc.Number()
+	if number < 0 {
+		return fmt.Errorf("%s: error code of %s must not be negative: %d",
+			f.Desc.Path(), ev.Desc.FullName(), number)
+	}
+	if prev, ok := codes[number]; ok {
+		return fmt.Errorf("%s: error code %d is used by both %s and %s",
+			f.Desc.Path(), number, prev.Desc.FullName(), ev.Desc.FullName())
+	}
+	codes[number] = ev
+	return nil
+}
+
 func (g *GenerateErrorDeclare) generateFile(
 	f *protogen.File,
 	values map[*protogen.EnumValue]*pberrors.EnumErrorDetail,
@@ -90,6 +116,11 @@ func (g *GenerateErrorDeclare) generateFile(
 
 	gf.P("// Code generated by protoc-gen-kit-errors. DO NOT EDIT.")
 	gf.P()
+	// the package field number of google.protobuf.FileDescriptorProto is 2
+	packageLocation := f.Desc.SourceLocations().ByPath(protoreflect.SourcePath{2})
+	if comment := protogen.Comments(packageLocation.LeadingComments); comment != "" {
+		gf.P(strings.TrimSpace(comment.String()))
+	}
 	gf.P("package ", f.GoPackageName)
 	gf.P()
 
@@ -112,7 +143,12 @@ func (g *GenerateErrorDeclare) generateFile(
 		}
 		sinkVarName = "Err" + strcase.ToCamel(lowSinkName)
 
-		gf.P(strings.TrimSpace(ev.Comments.Leading.String()))
+		comment := strings.TrimSpace(ev.Comments.Leading.String())
+		if trailing := strings.TrimSpace(ev.Comments.Trailing.String()); trailing != "" {
+			comment = strings.TrimSpace(comment + "\n" + trailing)
+		}
+
+		gf.P(comment)
 		gf.P(
 			"var ", sinkVarName, " = ",
 			gf.QualifiedGoIdent(errorsPackage.Ident("New")),
@@ -122,7 +158,7 @@ func (g *GenerateErrorDeclare) generateFile(
 			".SetDomainAndCode(\"", f.Proto.GetPackage(), "\", ", ev.Desc.Number(), ")",
 		)
 
-		gf.P(strings.TrimSpace(ev.Comments.Leading.String()))
+		gf.P(comment)
 		gf.P("// Deprecated: Use ", sinkVarName, " instead.")
 		gf.P("var ", varNameV1, " = ", sinkVarName)
 	}
@@ -11,6 +11,10 @@ const (
 	// which defined by nginx.
 	// https://httpstatus.in/499/
 	HttpCodeClientClosed = 499
+
+	// MetadataKeyHTTPStatus is the error info metadata key of the http status code which
+	// does not survive the conversion to a grpc code and back, e.g. 502.
+	MetadataKeyHTTPStatus = "http_status"
 )
 
 // Converter is a status converter.
@@ -41,10 +45,14 @@ func (c statusConverter) ToGRPCCode(code int) codes.Code {
 		return codes.PermissionDenied
 	case http.StatusNotFound:
 		return codes.NotFound
+	case http.StatusRequestTimeout:
+		return codes.DeadlineExceeded
 	case http.StatusConflict:
 		return codes.Aborted
 	case http.StatusPreconditionFailed:
 		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
 	case http.StatusRequestedRangeNotSatisfiable:
 		return codes.OutOfRange
 	case http.StatusTooManyRequests:
@@ -53,6 +61,8 @@ func (c statusConverter) ToGRPCCode(code int) codes.Code {
 		return codes.Internal
 	case http.StatusNotImplemented:
 		return codes.Unimplemented
+	case http.StatusBadGateway:
+		return codes.Unavailable
 	case http.StatusServiceUnavailable:
 		return codes.Unavailable
 	case http.StatusGatewayTimeout:
@@ -98,6 +108,8 @@ func (c statusConverter) FromGRPCCode(code codes.Code) int {
 	case codes.Internal:
 		return http.StatusInternalServerError
 	case codes.Unavailable:
+		// both 502 and 503 are mapped to Unavailable, it is converted back to 503.
+		// Error keeps 502 in MetadataKeyHTTPStatus across grpc.
 		return http.StatusServiceUnavailable
 	case codes.DataLoss:
 		return http.StatusInternalServerError
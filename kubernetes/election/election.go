@@ -2,12 +2,14 @@ package election
 
 import (
 	"context"
+	crand "crypto/rand"
 	"fmt"
-	"io"
 	"log/slog"
+	"math/big"
 	"os"
-	"strings"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,6 +17,8 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	kitkubernetes "github.com/crypto-zero/go-kit/kubernetes"
 )
 
 type (
@@ -42,6 +46,10 @@ type (
 
 		// AddMachine adds a state machine.
 		AddMachine(machine StateMachine)
+		// Done returns a channel that is closed after all state machines have stopped.
+		Done() <-chan struct{}
+		// PanicCount returns the number of recovered panics of the named state machine.
+		PanicCount(name string) int64
 	}
 )
 
@@ -49,15 +57,49 @@ type (
 type StateMachiRunnerImpl struct {
 	ctx    context.Context
 	cancel func()
-	closed bool
+	closed atomic.Bool
+
+	wg   sync.WaitGroup
+	done chan struct{}
 
-	wg sync.WaitGroup
+	panicsMu sync.Mutex
+	panics   map[string]*atomic.Int64
 
 	cli       *kubernetes.Clientset
 	namespace string
 	pod       string
 
 	logger *slog.Logger
+
+	electionJitter time.Duration
+	doTimeout      time.Duration
+}
+
+// Option configures the state machine runner.
+type Option func(s *StateMachiRunnerImpl)
+
+// WithElectionJitter delays the first leader election of each state machine by a random
+// duration in [0, maxJitter) to stagger the elections of pods restarted at the same time.
+func WithElectionJitter(maxJitter time.Duration) Option {
+	return func(s *StateMachiRunnerImpl) { s.electionJitter = maxJitter }
+}
+
+// WithDoTimeout stops serving a state machine whose Do does not return within d,
+// the context of Do is cancelled but a Do ignoring it is left behind. 0 means unlimited.
+func WithDoTimeout(d time.Duration) Option {
+	return func(s *StateMachiRunnerImpl) { s.doTimeout = d }
+}
+
+// randomJitter returns a crypto random duration in [0, maxJitter), 0 is returned when maxJitter <= 0.
+func randomJitter(maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	n, err := crand.Int(crand.Reader, big.NewInt(int64(maxJitter)))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(n.Int64())
 }
 
 // Start starts the state machine runner.
@@ -68,13 +110,42 @@ func (s *StateMachiRunnerImpl) Stop(context.Context) error { return nil }
 
 // cleanup cleans up the state machine runner.
 func (s *StateMachiRunnerImpl) cleanup() {
-	s.closed = true
+	s.closed.Store(true)
 	s.cancel()
 	s.wg.Wait()
+	close(s.done)
+}
+
+// Done returns a channel that is closed after all state machines have stopped.
+func (s *StateMachiRunnerImpl) Done() <-chan struct{} { return s.done }
+
+// panicCounter returns the panic counter of the named state machine.
+func (s *StateMachiRunnerImpl) panicCounter(name string) *atomic.Int64 {
+	s.panicsMu.Lock()
+	defer s.panicsMu.Unlock()
+	counter, ok := s.panics[name]
+	if !ok {
+		counter = new(atomic.Int64)
+		s.panics[name] = counter
+	}
+	return counter
+}
+
+// PanicCount returns the number of recovered panics of the named state machine.
+func (s *StateMachiRunnerImpl) PanicCount(name string) int64 {
+	s.panicsMu.Lock()
+	counter, ok := s.panics[name]
+	s.panicsMu.Unlock()
+	if !ok {
+		return 0
+	}
+	return counter.Load()
 }
 
 // serveMachine serves the state machine.
 func (s *StateMachiRunnerImpl) serveMachine(machine StateMachine) {
+	defer s.wg.Done()
+
 	// The logger name is conventionally assigned to the key "__LOGGER.NAMED__" defined in go-kit/zap.
 	const (
 		LoggerNamed = "__LOGGER.NAMED__"
@@ -127,14 +198,41 @@ func (s *StateMachiRunnerImpl) serveMachine(machine StateMachine) {
 
 	ctx, cancel := context.WithCancel(s.ctx)
 
-	defer s.wg.Done()
 	defer func() { logger.Info("stopped") }()
 	defer machine.Cleanup()
 	defer cancel()
 
-	go func() { le.Run(ctx) }()
+	go func() {
+		if jitter := randomJitter(s.electionJitter); jitter > 0 {
+			logger.Info("delaying leader election", "jitter", jitter)
+			select {
+			case <-time.After(jitter):
+			case <-ctx.Done():
+				return
+			}
+		}
+		le.Run(ctx)
+	}()
 
 	logger.Info("started")
+	s.runMachine(ctx, logger, machine, isLeaderChan)
+}
+
+// runMachine runs the state machine until ctx is done or the runner is closed,
+// a panic of the state machine is recovered, logged and counted.
+func (s *StateMachiRunnerImpl) runMachine(ctx context.Context, logger *slog.Logger, machine StateMachine,
+	isLeaderChan <-chan bool,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.panicCounter(machine.Name()).Add(1)
+			stack := debug.Stack()
+			if p, ok := r.(*doPanic); ok {
+				r, stack = p.value, p.stack
+			}
+			logger.Error("recovered from panic", "panic", r, "stack", string(stack))
+		}
+	}()
 
 	var isLeader bool
 	ensure := func() bool {
@@ -161,8 +259,12 @@ func (s *StateMachiRunnerImpl) serveMachine(machine StateMachine) {
 		}
 	}
 
-	for !s.closed {
-		after := machine.Do(ctx)
+	for !s.closed.Load() {
+		after, err := s.doWithTimeout(ctx, machine)
+		if err != nil {
+			logger.Error("state machine do timed out", "timeout", s.doTimeout, "err", err)
+			return
+		}
 		if after <= 0 {
 			return
 		}
@@ -178,15 +280,67 @@ func (s *StateMachiRunnerImpl) serveMachine(machine StateMachine) {
 	}
 }
 
+// doPanic is a panic of Do recovered in another goroutine, it keeps the original stack.
+type doPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *doPanic) Error() string { return fmt.Sprintf("%v\n%s", p.value, p.stack) }
+
+// doWithTimeout calls machine.Do, context.DeadlineExceeded is returned when it does not return
+// within doTimeout. on timeout the context of Do is cancelled and doWithTimeout returns without
+// waiting for Do, so a deadlocked Do never blocks shutdown. a panic of Do is propagated to the
+// caller as *doPanic.
+func (s *StateMachiRunnerImpl) doWithTimeout(ctx context.Context, machine StateMachine) (
+	after time.Duration, err error,
+) {
+	if s.doTimeout <= 0 {
+		return machine.Do(ctx), nil
+	}
+	type result struct {
+		after     time.Duration
+		recovered *doPanic
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// results is buffered, so a Do returning after the timeout never blocks its goroutine.
+	results := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				results <- result{recovered: &doPanic{value: r, stack: debug.Stack()}}
+			}
+		}()
+		results <- result{after: machine.Do(ctx)}
+	}()
+	timer := time.NewTimer(s.doTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-results:
+		if r.recovered != nil {
+			panic(r.recovered)
+		}
+		return r.after, nil
+	case <-timer.C:
+		return 0, context.DeadlineExceeded
+	}
+}
+
 func (s *StateMachiRunnerImpl) AddMachine(machine StateMachine) {
 	s.wg.Add(1)
 	go s.serveMachine(machine)
 }
 
 // NewStateMachineRunnerImpl creates a new StateMachineRunner.
-func NewStateMachineRunnerImpl(logger *slog.Logger) (StateMachineRunner, func(), error) {
+func NewStateMachineRunnerImpl(logger *slog.Logger, opts ...Option) (StateMachineRunner, func(), error) {
 	out := &StateMachiRunnerImpl{
 		logger: logger,
+		done:   make(chan struct{}),
+		panics: make(map[string]*atomic.Int64),
+	}
+	for _, opt := range opts {
+		opt(out)
 	}
 	out.ctx, out.cancel = context.WithCancel(context.Background())
 
@@ -207,15 +361,7 @@ func NewStateMachineRunnerImpl(logger *slog.Logger) (StateMachineRunner, func(),
 }
 
 // GetCurrentNamespace returns the current namespace in the kubernetes cluster.
+// it is cached by the go-kit kubernetes package.
 func GetCurrentNamespace() (namespace string) {
-	namespaceFile, err := os.Open("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
-	if err != nil {
-		return ""
-	}
-	d, err := io.ReadAll(namespaceFile)
-	if err != nil {
-		return ""
-	}
-	namespace = strings.TrimSpace(string(d))
-	return
+	return kitkubernetes.GetCurrentNamespace()
 }
@@ -0,0 +1,221 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMockStateMachine(t *testing.T) {
+	m := NewMockStateMachine("mock")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = m.EnsureMaster(context.Background())
+			_ = m.EnsureSlave(context.Background())
+		}()
+	}
+	wg.Wait()
+	m.Do(context.Background())
+	m.Cleanup()
+
+	m.AssertMasterCount(t, 10)
+	m.AssertSlaveCount(t, 10)
+	if calls := m.Calls(); len(calls) != 22 || calls[21] != MockCallCleanup {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+func TestRandomJitter(t *testing.T) {
+	if jitter := randomJitter(0); jitter != 0 {
+		t.Fatalf("expected no jitter, got %v", jitter)
+	}
+	for i := 0; i < 100; i++ {
+		if jitter := randomJitter(time.Second); jitter < 0 || jitter >= time.Second {
+			t.Fatalf("jitter out of range: %v", jitter)
+		}
+	}
+	s := &StateMachiRunnerImpl{}
+	WithElectionJitter(time.Second)(s)
+	if s.electionJitter != time.Second {
+		t.Fatalf("expected election jitter to be set, got %v", s.electionJitter)
+	}
+}
+
+// blockingStateMachine is a StateMachine whose Do blocks until release is closed, it ignores ctx.
+type blockingStateMachine struct {
+	*MockStateMachine
+	release chan struct{}
+}
+
+func (m *blockingStateMachine) Do(ctx context.Context) time.Duration {
+	<-m.release
+	return m.MockStateMachine.Do(ctx)
+}
+
+// panicStateMachine is a StateMachine whose Do always panics.
+type panicStateMachine struct {
+	*MockStateMachine
+}
+
+func (m *panicStateMachine) Do(context.Context) time.Duration { panic("do panic") }
+
+func TestDoWithTimeout(t *testing.T) {
+	s := &StateMachiRunnerImpl{}
+	machine := &blockingStateMachine{MockStateMachine: NewMockStateMachine("blocking"), release: make(chan struct{})}
+	defer close(machine.release)
+
+	if _, err := s.doWithTimeout(context.Background(), NewMockStateMachine("mock")); err != nil {
+		t.Fatalf("expected Do without timeout to return, got %v", err)
+	}
+	WithDoTimeout(10 * time.Millisecond)(s)
+	if _, err := s.doWithTimeout(context.Background(), machine); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected blocking Do to time out, got %v", err)
+	}
+	if after, err := s.doWithTimeout(context.Background(), NewMockStateMachine("mock")); err != nil ||
+		after != time.Second {
+		t.Fatalf("expected Do to return within timeout, got %v %v", after, err)
+	}
+}
+
+func TestDoWithTimeout_Panic(t *testing.T) {
+	s := &StateMachiRunnerImpl{}
+	WithDoTimeout(time.Second)(s)
+	defer func() {
+		p, ok := recover().(*doPanic)
+		if !ok {
+			t.Fatal("expected Do panic to be propagated as *doPanic")
+		}
+		if p.value != "do panic" {
+			t.Fatalf("unexpected panic value: %v", p.value)
+		}
+		if !strings.Contains(string(p.stack), "panicStateMachine") {
+			t.Fatalf("expected stack of the Do goroutine, got %s", p.stack)
+		}
+	}()
+	s.doWithTimeout(context.Background(), &panicStateMachine{MockStateMachine: NewMockStateMachine("panic")})
+}
+
+func TestRunMachine_Panic(t *testing.T) {
+	s := &StateMachiRunnerImpl{panics: make(map[string]*atomic.Int64)}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	if count := s.PanicCount("panic"); count != 0 {
+		t.Fatalf("expected no panics, got %d", count)
+	}
+	if len(s.panics) != 0 {
+		t.Fatal("PanicCount must not insert a counter")
+	}
+
+	for _, timeout := range []time.Duration{0, time.Second} {
+		s.doTimeout = timeout
+		isLeaderChan := make(chan bool, 1)
+		isLeaderChan <- true
+		s.runMachine(context.Background(), logger, &panicStateMachine{MockStateMachine: NewMockStateMachine("panic")},
+			isLeaderChan)
+	}
+	if count := s.PanicCount("panic"); count != 2 {
+		t.Fatalf("expected 2 panics, got %d", count)
+	}
+
+	// the runner keeps running other state machines after a panic.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	machine := NewMockStateMachine("mock")
+	isLeaderChan := make(chan bool, 1)
+	isLeaderChan <- true
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.runMachine(ctx, logger, machine, isLeaderChan)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for machine.CallCount(MockCallDo) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the state machine to run after a panic of another one")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	machine.AssertMasterCount(t, 1)
+	if count := s.PanicCount("mock"); count != 0 {
+		t.Fatalf("expected no panics of mock, got %d", count)
+	}
+}
+
+// newTestRunner returns a runner without a kubernetes client and its cleanup func.
+func newTestRunner(opts ...Option) (*StateMachiRunnerImpl, func()) {
+	s := &StateMachiRunnerImpl{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		done:   make(chan struct{}),
+		panics: make(map[string]*atomic.Int64),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s, sync.OnceFunc(s.cleanup)
+}
+
+// addTestMachine serves machine as the leader like serveMachine without leader election.
+func addTestMachine(s *StateMachiRunnerImpl, machine StateMachine) {
+	isLeaderChan := make(chan bool, 1)
+	isLeaderChan <- true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer machine.Cleanup()
+		s.runMachine(s.ctx, s.logger, machine, isLeaderChan)
+	}()
+}
+
+func TestStateMachineRunnerDone(t *testing.T) {
+	s, cleanup := newTestRunner()
+	machine := NewMockStateMachine("mock")
+	addTestMachine(s, machine)
+
+	select {
+	case <-s.Done():
+		t.Fatal("done channel closed before cleanup")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cleanup()
+	cleanup()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-s.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("done channel is not closed after cleanup")
+		}
+	}
+	if calls := machine.Calls(); calls[len(calls)-1] != MockCallCleanup {
+		t.Fatalf("expected the state machine to be cleaned up before done, got %v", calls)
+	}
+}
+
+func TestStateMachineRunnerDone_DeadlockedDo(t *testing.T) {
+	s, cleanup := newTestRunner(WithDoTimeout(10 * time.Millisecond))
+	machine := &blockingStateMachine{MockStateMachine: NewMockStateMachine("blocking"), release: make(chan struct{})}
+	defer close(machine.release)
+	addTestMachine(s, machine)
+
+	cleanup()
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("done channel is not closed with a deadlocked Do")
+	}
+	if machine.CallCount(MockCallCleanup) != 1 {
+		t.Fatalf("expected the timed out state machine to be cleaned up, got %v", machine.Calls())
+	}
+}
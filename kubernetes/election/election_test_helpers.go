@@ -0,0 +1,110 @@
+//go:build !production
+
+package election
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// MockCallEnsureMaster is the recorded call name of EnsureMaster.
+	MockCallEnsureMaster = "EnsureMaster"
+	// MockCallEnsureSlave is the recorded call name of EnsureSlave.
+	MockCallEnsureSlave = "EnsureSlave"
+	// MockCallDo is the recorded call name of Do.
+	MockCallDo = "Do"
+	// MockCallCleanup is the recorded call name of Cleanup.
+	MockCallCleanup = "Cleanup"
+)
+
+// MockStateMachine is a StateMachine that records its calls for testing.
+type MockStateMachine struct {
+	// MachineName is returned by Name.
+	MachineName string
+	// After is returned by Do.
+	After time.Duration
+	// MasterErr is returned by EnsureMaster.
+	MasterErr error
+	// SlaveErr is returned by EnsureSlave.
+	SlaveErr error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewMockStateMachine returns a MockStateMachine with name.
+func NewMockStateMachine(name string) *MockStateMachine {
+	return &MockStateMachine{MachineName: name, After: time.Second}
+}
+
+// record records a call.
+func (m *MockStateMachine) record(call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+}
+
+// Name returns the name of the state machine.
+func (m *MockStateMachine) Name() string { return m.MachineName }
+
+// EnsureMaster records the call and returns MasterErr.
+func (m *MockStateMachine) EnsureMaster(context.Context) error {
+	m.record(MockCallEnsureMaster)
+	return m.MasterErr
+}
+
+// EnsureSlave records the call and returns SlaveErr.
+func (m *MockStateMachine) EnsureSlave(context.Context) error {
+	m.record(MockCallEnsureSlave)
+	return m.SlaveErr
+}
+
+// Do records the call and returns After.
+func (m *MockStateMachine) Do(context.Context) time.Duration {
+	m.record(MockCallDo)
+	return m.After
+}
+
+// Cleanup records the call.
+func (m *MockStateMachine) Cleanup() { m.record(MockCallCleanup) }
+
+// Calls returns a copy of the recorded calls in order.
+func (m *MockStateMachine) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// CallCount returns the number of recorded calls named call.
+func (m *MockStateMachine) CallCount(call string) (n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.calls {
+		if c == call {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertMasterCount fails t when EnsureMaster was not called n times.
+func (m *MockStateMachine) AssertMasterCount(t testing.TB, n int) {
+	t.Helper()
+	if got := m.CallCount(MockCallEnsureMaster); got != n {
+		t.Errorf("expected EnsureMaster to be called %d times, got %d", n, got)
+	}
+}
+
+// AssertSlaveCount fails t when EnsureSlave was not called n times.
+func (m *MockStateMachine) AssertSlaveCount(t testing.TB, n int) {
+	t.Helper()
+	if got := m.CallCount(MockCallEnsureSlave); got != n {
+		t.Errorf("expected EnsureSlave to be called %d times, got %d", n, got)
+	}
+}
+
+var _ StateMachine = (*MockStateMachine)(nil)
@@ -1,21 +1,37 @@
 package kubernetes
 
 import (
-	"io"
 	"os"
 	"strings"
+	"sync"
+)
+
+// namespaceFile is the service account namespace file, it is variable for testing.
+var namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+var (
+	currentNamespaceMu     sync.Mutex
+	currentNamespaceCached bool
+	currentNamespace       string
 )
 
 // GetCurrentNamespace returns the current namespace in the kubernetes cluster.
+// the namespace never changes for a running pod, so it is read once and cached.
 func GetCurrentNamespace() (namespace string) {
-	namespaceFile, err := os.Open("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
-	if err != nil {
-		return ""
+	currentNamespaceMu.Lock()
+	defer currentNamespaceMu.Unlock()
+	if !currentNamespaceCached {
+		currentNamespaceCached = true
+		if d, err := os.ReadFile(namespaceFile); err == nil {
+			currentNamespace = strings.TrimSpace(string(d))
+		}
 	}
-	d, err := io.ReadAll(namespaceFile)
-	if err != nil {
-		return ""
-	}
-	namespace = strings.TrimSpace(string(d))
-	return
+	return currentNamespace
+}
+
+// ResetCurrentNamespaceCache resets the cached namespace, it is intended for testing only.
+func ResetCurrentNamespaceCache() {
+	currentNamespaceMu.Lock()
+	defer currentNamespaceMu.Unlock()
+	currentNamespaceCached, currentNamespace = false, ""
 }
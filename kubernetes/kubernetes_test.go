@@ -0,0 +1,35 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetCurrentNamespace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "namespace")
+	if err := os.WriteFile(path, []byte("default\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	origin := namespaceFile
+	namespaceFile = path
+	t.Cleanup(func() {
+		namespaceFile = origin
+		ResetCurrentNamespaceCache()
+	})
+	ResetCurrentNamespaceCache()
+
+	if ns := GetCurrentNamespace(); ns != "default" {
+		t.Fatalf("expected namespace default, got %q", ns)
+	}
+	if err := os.WriteFile(path, []byte("changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if ns := GetCurrentNamespace(); ns != "default" {
+		t.Fatalf("expected cached namespace default, got %q", ns)
+	}
+	ResetCurrentNamespaceCache()
+	if ns := GetCurrentNamespace(); ns != "changed" {
+		t.Fatalf("expected namespace changed after reset, got %q", ns)
+	}
+}
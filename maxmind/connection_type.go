@@ -0,0 +1,52 @@
+package maxmind
+
+import (
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// ConnectionTypeRecord is a struct for maxminddb connection type result
+type ConnectionTypeRecord struct {
+	// ConnectionType is one of "Dialup", "Cable/DSL", "Corporate", "Cellular" and "Satellite"
+	ConnectionType string `maxminddb:"connection_type"`
+}
+
+// ConnectionTypeDatabase is an interface for maxminddb connection type database
+type ConnectionTypeDatabase interface {
+	// Lookup returns ConnectionTypeRecord for given IP
+	Lookup(ip net.IP) (*ConnectionTypeRecord, error)
+}
+
+// ConnectionTypeDatabaseImpl is an implementation of ConnectionTypeDatabase
+type ConnectionTypeDatabaseImpl struct {
+	db *maxminddb.Reader
+}
+
+func (d *ConnectionTypeDatabaseImpl) Lookup(ip net.IP) (*ConnectionTypeRecord, error) {
+	var record ConnectionTypeRecord
+	if err := d.db.Lookup(ip, &record); err != nil {
+		return nil, err
+	}
+	if record.ConnectionType == "" {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// ConnectionTypePath returns path to maxminddb connection type container
+func ConnectionTypePath() Path {
+	return "/app/bin/GeoIP2-Connection-Type.mmdb"
+}
+
+// NewConnectionTypeDatabaseImpl returns implementation of ConnectionTypeDatabase.
+// it requires the paid GeoIP2 Connection Type database, GeoLite2 does not provide it.
+func NewConnectionTypeDatabaseImpl(path Path) (ConnectionTypeDatabase, func(), error) {
+	db, err := maxminddb.Open(string(path))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &ConnectionTypeDatabaseImpl{db: db}, func() {
+		_ = db.Close()
+	}, nil
+}
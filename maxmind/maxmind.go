@@ -1,8 +1,15 @@
 package maxmind
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net"
+	"os"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/oschwald/maxminddb-golang"
 )
@@ -51,6 +58,68 @@ type GeoCity struct {
 	} `maxminddb:"subdivisions"`
 }
 
+// UnknownCountryCode is returned by CountryCode when the country is unknown
+const UnknownCountryCode = "XX"
+
+// languages returns the language codes and names in field order
+func (n *GeoNames) languages() [8][2]string {
+	return [8][2]string{
+		{"de", n.German}, {"en", n.English}, {"es", n.Spanish}, {"fr", n.French},
+		{"ja", n.Japanese}, {"pt-BR", n.BrazilianPortuguese}, {"ru", n.Russia}, {"zh-CN", n.Chinese},
+	}
+}
+
+// Name returns the name in lang, it falls back to English and then to the first available name
+func (n *GeoNames) Name(lang string) string {
+	languages := n.languages()
+	for _, language := range languages {
+		if strings.EqualFold(language[0], lang) && language[1] != "" {
+			return language[1]
+		}
+	}
+	if n.English != "" {
+		return n.English
+	}
+	for _, language := range languages {
+		if language[1] != "" {
+			return language[1]
+		}
+	}
+	return ""
+}
+
+// CountryCode returns the ISO country code, UnknownCountryCode is returned when it is unknown
+func (g *GeoCity) CountryCode() string {
+	if g == nil || g.Country.ISO == "" {
+		return UnknownCountryCode
+	}
+	return g.Country.ISO
+}
+
+// CityName returns the city name in lang, it falls back to English and then to the first available name
+func (g *GeoCity) CityName(lang string) string {
+	if g == nil {
+		return ""
+	}
+	return g.City.Name.Name(lang)
+}
+
+// RegionCode returns the ISO code of the most general subdivision
+func (g *GeoCity) RegionCode() string {
+	if g == nil || len(g.Subdivisions) == 0 {
+		return ""
+	}
+	return g.Subdivisions[0].ISO
+}
+
+// Timezone returns the time zone of the location
+func (g *GeoCity) Timezone() string {
+	if g == nil {
+		return ""
+	}
+	return g.Location.TimeZone
+}
+
 var emptyGeoCity = GeoCity{}
 
 // Database is an interface for maxminddb
@@ -85,6 +154,17 @@ func ContainerPath() Path {
 
 // NewDatabaseImpl returns implementation of Database
 func NewDatabaseImpl(path Path) (Database, func(), error) {
+	return NewDatabaseImplWithChecksum(path, "")
+}
+
+// NewDatabaseImplWithChecksum returns implementation of Database,
+// the database file is verified with expectedMD5 first when it is not empty.
+func NewDatabaseImplWithChecksum(path Path, expectedMD5 string) (Database, func(), error) {
+	if expectedMD5 != "" {
+		if err := VerifyDatabaseIntegrity(path, expectedMD5); err != nil {
+			return nil, nil, err
+		}
+	}
 	db, err := maxminddb.Open(string(path))
 	if err != nil {
 		return nil, nil, err
@@ -94,7 +174,65 @@ func NewDatabaseImpl(path Path) (Database, func(), error) {
 	}, nil
 }
 
+// VerifyDatabaseIntegrity checks the MD5 checksum of the database file at path
+func VerifyDatabaseIntegrity(path Path, expectedMD5 string) error {
+	f, err := os.Open(string(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to read maxmind database %s: %w", path, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, strings.TrimSpace(expectedMD5)) {
+		return fmt.Errorf("maxmind database %s checksum mismatch: expected %s, got %s", path, expectedMD5, got)
+	}
+	return nil
+}
+
 // IsEmptyGeoCity checks if GeoCity is empty
 func IsEmptyGeoCity(geoCity GeoCity) bool {
 	return reflect.DeepEqual(geoCity, emptyGeoCity)
 }
+
+// anonymousProxyCountryCodes are legacy country codes maxmind used for anonymous and satellite providers
+var anonymousProxyCountryCodes = []string{"A1", "A2"}
+
+// IsLikelyProxy reports whether the country of the IP differs from its registered country.
+// it is a heuristic fraud signal only, legitimate roaming and multinational networks also match.
+func IsLikelyProxy(record *GeoCity) bool {
+	if record == nil || record.Country.ISO == "" || record.RegisteredCountry.ISO == "" {
+		return false
+	}
+	return record.Country.ISO != record.RegisteredCountry.ISO
+}
+
+// IsAnonymousProxy reports whether the country code is one of the anonymous proxy codes A1 or A2.
+// it is a heuristic fraud signal only, newer databases no longer emit these codes.
+func IsAnonymousProxy(record *GeoCity) bool {
+	if record == nil {
+		return false
+	}
+	return slices.Contains(anonymousProxyCountryCodes, record.Country.ISO) ||
+		slices.Contains(anonymousProxyCountryCodes, record.RegisteredCountry.ISO)
+}
+
+// FallbackDatabase is a Database that falls back to another Database
+type FallbackDatabase struct {
+	primary, fallback Database
+}
+
+// Lookup returns GeoCity from primary, or from fallback when primary has no result or fails
+func (d *FallbackDatabase) Lookup(ip net.IP) (*GeoCity, error) {
+	if record, err := d.primary.Lookup(ip); err == nil && record != nil {
+		return record, nil
+	}
+	return d.fallback.Lookup(ip)
+}
+
+// NewFallbackDatabase returns a Database that looks up fallback when primary fails,
+// e.g. while the primary database file is being updated
+func NewFallbackDatabase(primary, fallback Database) Database {
+	return &FallbackDatabase{primary: primary, fallback: fallback}
+}
@@ -1,11 +1,18 @@
 package maxmind
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/oschwald/maxminddb-golang"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestMaxmindRead(t *testing.T) {
@@ -22,3 +29,122 @@ func TestMaxmindRead(t *testing.T) {
 	b, _ := json.Marshal(record)
 	t.Log(string(b), IsEmptyGeoCity(record))
 }
+
+func TestIsLikelyProxy(t *testing.T) {
+	var record GeoCity
+	if IsLikelyProxy(&record) || IsAnonymousProxy(&record) {
+		t.Fatal("empty record should not be treated as proxy")
+	}
+	record.Country.ISO, record.RegisteredCountry.ISO = "US", "GB"
+	if !IsLikelyProxy(&record) {
+		t.Fatal("country mismatch should be treated as likely proxy")
+	}
+	record.Country.ISO = "A1"
+	if !IsAnonymousProxy(&record) {
+		t.Fatal("A1 should be treated as anonymous proxy")
+	}
+}
+
+type staticDatabase struct {
+	record *GeoCity
+	err    error
+}
+
+func (d staticDatabase) Lookup(net.IP) (*GeoCity, error) { return d.record, d.err }
+
+func TestFallbackDatabase(t *testing.T) {
+	primary, fallback := &GeoCity{}, &GeoCity{}
+	primary.Country.ISO, fallback.Country.ISO = "US", "GB"
+
+	db := NewFallbackDatabase(staticDatabase{record: primary}, staticDatabase{record: fallback})
+	if record, _ := db.Lookup(nil); record != primary {
+		t.Fatal("expected primary record")
+	}
+	db = NewFallbackDatabase(staticDatabase{err: errors.New("closed")}, staticDatabase{record: fallback})
+	if record, _ := db.Lookup(nil); record != fallback {
+		t.Fatal("expected fallback record on primary error")
+	}
+	db = NewFallbackDatabase(staticDatabase{}, staticDatabase{record: fallback})
+	if record, _ := db.Lookup(nil); record != fallback {
+		t.Fatal("expected fallback record on primary miss")
+	}
+}
+
+func TestVerifyDatabaseIntegrity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(path, []byte("maxmind"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := md5.Sum([]byte("maxmind"))
+	if err := VerifyDatabaseIntegrity(Path(path), hex.EncodeToString(sum[:])); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDatabaseIntegrity(Path(path), "00000000000000000000000000000000"); err == nil {
+		t.Fatal("expected checksum mismatch error")
+	}
+	if _, _, err := NewDatabaseImplWithChecksum(Path(path), "bad"); err == nil {
+		t.Fatal("expected NewDatabaseImplWithChecksum to fail on checksum mismatch")
+	}
+}
+
+func TestGeoCityAccessors(t *testing.T) {
+	var nilCity *GeoCity
+	if nilCity.CountryCode() != UnknownCountryCode || nilCity.CityName("en") != "" {
+		t.Fatal("unexpected nil GeoCity accessors result")
+	}
+
+	var record GeoCity
+	if record.CountryCode() != UnknownCountryCode {
+		t.Fatal("expected unknown country code")
+	}
+	record.Country.ISO = "GB"
+	record.City.Name.Japanese = "ロンドン"
+	record.Location.TimeZone = "Europe/London"
+	record.Subdivisions = append(record.Subdivisions, struct {
+		ISO   string   `maxminddb:"iso_code"`
+		Names GeoNames `maxminddb:"names"`
+	}{ISO: "ENG"})
+	if record.CityName("de") != "ロンドン" {
+		t.Fatal("expected fallback to the first available city name")
+	}
+	record.City.Name.English = "London"
+	record.City.Name.Chinese = "伦敦"
+	if record.CityName("zh-cn") != "伦敦" || record.CityName("fr") != "London" {
+		t.Fatal("unexpected city name")
+	}
+	if record.CountryCode() != "GB" || record.RegionCode() != "ENG" || record.Timezone() != "Europe/London" {
+		t.Fatal("unexpected GeoCity accessors result")
+	}
+}
+
+func TestInstrumentedDatabase(t *testing.T) {
+	inner := &staticDatabase{record: &GeoCity{}}
+	reg := prometheus.NewRegistry()
+	db := InstrumentedDatabase(inner, reg)
+	_, _ = db.Lookup(nil)
+	inner.record = nil
+	_, _ = db.Lookup(nil)
+	_, _ = db.Lookup(nil)
+	inner.err = errors.New("closed")
+	_, _ = db.Lookup(nil)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch family.GetName() {
+			case "maxmind_lookups_total":
+				counts[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+			case "maxmind_lookup_duration_seconds":
+				counts["observed"] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	want := map[string]float64{lookupResultHit: 1, lookupResultMiss: 2, lookupResultError: 1, "observed": 4}
+	if !reflect.DeepEqual(counts, want) {
+		t.Fatalf("unexpected metrics: %v", counts)
+	}
+}
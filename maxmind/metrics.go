@@ -0,0 +1,57 @@
+package maxmind
+
+import (
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// lookup results of maxmind_lookups_total
+const (
+	lookupResultHit   = "hit"
+	lookupResultMiss  = "miss"
+	lookupResultError = "error"
+)
+
+// instrumentedDatabase is a Database recording prometheus metrics of lookups
+type instrumentedDatabase struct {
+	inner    Database
+	lookups  *prometheus.CounterVec
+	duration prometheus.Histogram
+}
+
+func (d *instrumentedDatabase) Lookup(ip net.IP) (*GeoCity, error) {
+	start := time.Now()
+	record, err := d.inner.Lookup(ip)
+	d.duration.Observe(time.Since(start).Seconds())
+	switch {
+	case err != nil:
+		d.lookups.WithLabelValues(lookupResultError).Inc()
+	case record == nil:
+		d.lookups.WithLabelValues(lookupResultMiss).Inc()
+	default:
+		d.lookups.WithLabelValues(lookupResultHit).Inc()
+	}
+	return record, err
+}
+
+// InstrumentedDatabase returns a Database recording maxmind_lookups_total{result="hit|miss|error"}
+// and maxmind_lookup_duration_seconds of inner to reg, a lookup without record is a miss.
+// it panics when the metrics are already registered to reg.
+func InstrumentedDatabase(inner Database, reg prometheus.Registerer) Database {
+	d := &instrumentedDatabase{
+		inner: inner,
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "maxmind_lookups_total",
+			Help: "Total number of maxmind lookups by result.",
+		}, []string{"result"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "maxmind_lookup_duration_seconds",
+			Help:    "Duration of maxmind lookups in seconds.",
+			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05},
+		}),
+	}
+	reg.MustRegister(d.lookups, d.duration)
+	return d
+}
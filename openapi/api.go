@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"io"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
 
 // GenerateGRPCFullMethodNamesByTag generates full method names by tag
 func GenerateGRPCFullMethodNamesByTag(fs *embed.FS, tag string) (out []string, err error) {
+	return GenerateGRPCFullMethodNamesByTagAndMethod(fs, tag, "")
+}
+
+// GenerateGRPCFullMethodNamesByTagAndMethod generates full method names by tag and http method,
+// empty method matches all methods.
+func GenerateGRPCFullMethodNamesByTagAndMethod(fs *embed.FS, tag, method string) (
+	out []string, err error,
+) {
 	apis, err := GenerateOpenAPI(fs)
 	if err != nil {
 		return nil, err
@@ -21,6 +31,9 @@ func GenerateGRPCFullMethodNamesByTag(fs *embed.FS, tag string) (out []string, e
 			if !slices.Contains(path.Tags, tag) {
 				continue
 			}
+			if method != "" && !strings.EqualFold(path.Method, method) {
+				continue
+			}
 			p := fmt.Sprintf("/%s.%s/%s", api.Version, path.ServiceName, path.MethodName)
 			out = append(out, p)
 		}
@@ -44,6 +57,8 @@ type OpenAPIPath struct {
 	ServiceName string
 	// MethodName split from OperationID
 	MethodName string
+	// SchemaName resolved from the application/json request body schema $ref
+	SchemaName string
 }
 
 // GenerateOpenAPI generates openapi from embed.FS
@@ -74,12 +89,90 @@ func GenerateOpenAPI(fs *embed.FS) (out []*OpenAPI, err error) {
 	return
 }
 
+// detectSpecVersion detects the major version from the openapi or swagger field,
+// it returns 0 when neither field is present.
+func detectSpecVersion(m map[string]interface{}) (major int, err error) {
+	versionNode, ok := m["openapi"]
+	if !ok {
+		if versionNode, ok = m["swagger"]; !ok {
+			return 0, nil
+		}
+	}
+	var version string
+	switch v := versionNode.(type) {
+	case string:
+		version = v
+	case int:
+		return v, nil
+	case float64:
+		version = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return 0, fmt.Errorf("invalid spec version type %T", versionNode)
+	}
+	majorStr, _, _ := strings.Cut(version, ".")
+	if major, err = strconv.Atoi(majorStr); err != nil {
+		return 0, fmt.Errorf("invalid spec version %q", version)
+	}
+	return major, nil
+}
+
+// openAPICacheEntry is a parsed openapi cache entry of an embed.FS
+type openAPICacheEntry struct {
+	once sync.Once
+	apis []*OpenAPI
+	err  error
+}
+
+// openAPICache caches *openAPICacheEntry by *embed.FS
+var openAPICache sync.Map
+
+// GenerateOpenAPICached generates openapi from embed.FS and caches the result per embed.FS,
+// embed.FS content is immutable so the cache is never invalidated.
+// the returned openapi objects are shared and must not be modified.
+func GenerateOpenAPICached(fs *embed.FS) ([]*OpenAPI, error) {
+	value, _ := openAPICache.LoadOrStore(fs, &openAPICacheEntry{})
+	entry := value.(*openAPICacheEntry)
+	entry.once.Do(func() { entry.apis, entry.err = GenerateOpenAPI(fs) })
+	return entry.apis, entry.err
+}
+
+// ResolveSchemaRef returns the schema name of a $ref such as #/components/schemas/User
+func ResolveSchemaRef(schemaRef string) string {
+	if i := strings.LastIndex(schemaRef, "/"); i >= 0 {
+		return schemaRef[i+1:]
+	}
+	return schemaRef
+}
+
+// resolveRequestBodySchemaRef returns the application/json request body schema $ref of an operation
+func resolveRequestBodySchemaRef(methodMap map[string]interface{}) string {
+	node := interface{}(methodMap)
+	for _, key := range []string{"requestBody", "content", "application/json", "schema", "$ref"} {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if node, ok = m[key]; !ok {
+			return ""
+		}
+	}
+	ref, _ := node.(string)
+	return ref
+}
+
 // ResolveAPIFile resolves api file
 func ResolveAPIFile(api *OpenAPI, file []byte) error {
 	m := make(map[string]interface{})
 	if err := yaml.Unmarshal(file, &m); err != nil {
 		return err
 	}
+	major, err := detectSpecVersion(m)
+	if err != nil {
+		return err
+	}
+	if major == 2 {
+		return fmt.Errorf("unsupported spec version 2.x, only openapi 3.x is supported")
+	}
 	infoNode, ok := m["info"]
 	if !ok {
 		return nil
@@ -151,6 +244,7 @@ func ResolveAPIFile(api *OpenAPI, file []byte) error {
 				Tags:        tagStrs,
 				ServiceName: serviceName,
 				MethodName:  methodName,
+				SchemaName:  ResolveSchemaRef(resolveRequestBodySchemaRef(methodMap)),
 			}
 			api.Paths = append(api.Paths, apiPath)
 		}
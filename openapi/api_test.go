@@ -3,6 +3,8 @@ package v1
 import (
 	"embed"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -25,3 +27,120 @@ func TestGenerateGRPCFullMethodNamesByTag(t *testing.T) {
 	}
 	t.Log(methods)
 }
+
+func TestDetectSpecVersion(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   map[string]interface{}
+		major   int
+		wantErr bool
+	}{
+		{name: "Missing", input: map[string]interface{}{}, major: 0},
+		{name: "OpenAPI3", input: map[string]interface{}{"openapi": "3.0.3"}, major: 3},
+		{name: "Swagger2", input: map[string]interface{}{"swagger": "2.0"}, major: 2},
+		{name: "Swagger2Float", input: map[string]interface{}{"swagger": 2.0}, major: 2},
+		{name: "Invalid", input: map[string]interface{}{"openapi": "x"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			major, err := detectSpecVersion(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if major != tc.major {
+				t.Fatalf("expected major %d, got %d", tc.major, major)
+			}
+		})
+	}
+	if err := ResolveAPIFile(&OpenAPI{}, []byte("swagger: \"2.0\"\n")); err == nil {
+		t.Fatal("expected error for swagger 2.0 spec")
+	}
+}
+
+func TestGenerateGRPCFullMethodNamesByTagAndMethod(t *testing.T) {
+	all, err := GenerateGRPCFullMethodNamesByTagAndMethod(&OpenAPIYAML, "Admin", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gets, err := GenerateGRPCFullMethodNamesByTagAndMethod(&OpenAPIYAML, "Admin", "GET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gets) == 0 || len(gets) >= len(all) {
+		t.Fatalf("expected GET methods to be a strict subset, got %d of %d", len(gets), len(all))
+	}
+	t.Log(gets)
+}
+
+func TestGenerateOpenAPICached(t *testing.T) {
+	first, err := GenerateOpenAPICached(&OpenAPIYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GenerateOpenAPICached(&OpenAPIYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) == 0 || first[0] != second[0] {
+		t.Fatal("expected cached openapi to be reused")
+	}
+}
+
+func TestResolveSchemaRef(t *testing.T) {
+	if name := ResolveSchemaRef("#/components/schemas/User"); name != "User" {
+		t.Fatalf("expected User, got %q", name)
+	}
+	if name := ResolveSchemaRef(""); name != "" {
+		t.Fatalf("expected empty name, got %q", name)
+	}
+
+	file := []byte(`openapi: 3.0.3
+info:
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      tags: [User]
+      operationId: UserService_CreateUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateUserRequest'
+`)
+	var api OpenAPI
+	if err := ResolveAPIFile(&api, file); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.Paths) != 1 || api.Paths[0].SchemaName != "CreateUserRequest" {
+		t.Fatalf("unexpected paths: %+v", api.Paths)
+	}
+}
+
+func TestNewEmbedFileServer(t *testing.T) {
+	server := NewEmbedFileServer(&OpenAPIYAML)
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/cms.openapi.yaml", nil))
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/yaml" {
+		t.Fatalf("unexpected response: %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	etag := recorder.Header().Get("ETag")
+	if etag == "" || recorder.Body.Len() == 0 {
+		t.Fatal("expected ETag and body")
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/cms.openapi.yaml", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing.openapi.yaml", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// embedFile is an openapi file served by NewEmbedFileServer
+type embedFile struct {
+	data        []byte
+	etag        string
+	contentType string
+}
+
+// embedFileContentType returns the content type of an openapi file name, empty if it is not served
+func embedFileContentType(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".openapi.yaml"):
+		return "application/yaml"
+	case strings.HasSuffix(name, ".openapi.json"):
+		return "application/json"
+	}
+	return ""
+}
+
+// embedFileServer serves openapi files by name
+type embedFileServer map[string]*embedFile
+
+func (s embedFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	file, ok := s[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", file.contentType)
+	w.Header().Set("ETag", file.etag)
+	// embed.FS has no modification time, ServeContent handles If-None-Match by ETag
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(file.data))
+}
+
+// NewEmbedFileServer returns a handler serving the .openapi.yaml and .openapi.json files
+// of fs under their file name, e.g. GET /user.openapi.yaml. it is intended for development.
+func NewEmbedFileServer(fs *embed.FS) http.Handler {
+	server := make(embedFileServer)
+	entries, err := fs.ReadDir(".")
+	if err != nil {
+		return server
+	}
+	for _, entry := range entries {
+		contentType := embedFileContentType(entry.Name())
+		if entry.IsDir() || contentType == "" {
+			continue
+		}
+		data, err := fs.ReadFile(entry.Name())
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		server[entry.Name()] = &embedFile{
+			data:        data,
+			etag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+			contentType: contentType,
+		}
+	}
+	return server
+}
@@ -0,0 +1,52 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier adapts grpc metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+// Get returns the first value associated with the key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set sets the value of the key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the carrier.
+func (c metadataCarrier) Keys() []string {
+	out := make([]string, 0, len(c))
+	for key := range c {
+		out = append(out, key)
+	}
+	return out
+}
+
+// InjectGRPCMetadata returns a copy of the outgoing grpc metadata in ctx with the span context
+// injected by the global propagator, see TraceProviderConfig.Propagator.
+func InjectGRPCMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return md
+}
+
+// ExtractGRPCMetadata returns a context with the span context extracted from the incoming
+// grpc metadata by the global propagator, see TraceProviderConfig.Propagator.
+func ExtractGRPCMetadata(ctx context.Context, md metadata.MD) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
@@ -0,0 +1,31 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestGRPCMetadataPropagation(t *testing.T) {
+	origin := otel.GetTextMapPropagator()
+	t.Cleanup(func() { otel.SetTextMapPropagator(origin) })
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	md := InjectGRPCMetadata(ctx)
+	if len(md.Get("traceparent")) == 0 {
+		t.Fatalf("traceparent is not injected: %v", md)
+	}
+	extracted := trace.SpanContextFromContext(ExtractGRPCMetadata(context.Background(), md))
+	if extracted.TraceID() != spanContext.TraceID() || extracted.SpanID() != spanContext.SpanID() {
+		t.Fatalf("expected %v, got %v", spanContext, extracted)
+	}
+}
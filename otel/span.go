@@ -0,0 +1,27 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDFromContext returns the 32 hex characters trace id of the span in ctx,
+// ok is false when the span context is not valid.
+func TraceIDFromContext(ctx context.Context) (traceID string, ok bool) {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.IsValid() {
+		return "", false
+	}
+	return spanContext.TraceID().String(), true
+}
+
+// SpanIDFromContext returns the 16 hex characters span id of the span in ctx,
+// ok is false when the span context is not valid.
+func SpanIDFromContext(ctx context.Context) (spanID string, ok bool) {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.IsValid() {
+		return "", false
+	}
+	return spanContext.SpanID().String(), true
+}
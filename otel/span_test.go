@@ -0,0 +1,29 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceIDAndSpanIDFromContext(t *testing.T) {
+	if _, ok := TraceIDFromContext(context.Background()); ok {
+		t.Fatal("expected no trace id without span")
+	}
+	if _, ok := SpanIDFromContext(context.Background()); ok {
+		t.Fatal("expected no span id without span")
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02},
+		SpanID:  trace.SpanID{0x03},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	if traceID, ok := TraceIDFromContext(ctx); !ok || traceID != "01020000000000000000000000000000" {
+		t.Fatalf("unexpected trace id %q", traceID)
+	}
+	if spanID, ok := SpanIDFromContext(ctx); !ok || spanID != "0300000000000000" {
+		t.Fatalf("unexpected span id %q", spanID)
+	}
+}
@@ -2,6 +2,7 @@ package otel
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -26,6 +28,89 @@ type TraceProviderConfig struct {
 	Endpoint       string
 	Insecure       bool
 	SampleFraction float64
+	// SamplerType is one of SamplerRatio, SamplerAlwaysOn, SamplerAlwaysOff and SamplerParentRatio,
+	// empty means SamplerRatio.
+	SamplerType string
+	// Propagator is set as the global text map propagator when it is not nil, the global
+	// propagator is left untouched otherwise. e.g. propagation.NewCompositeTextMapPropagator(
+	// propagation.TraceContext{}, propagation.Baggage{}).
+	Propagator propagation.TextMapPropagator
+
+	flagSet *flag.FlagSet
+	flags   traceProviderFlags
+}
+
+const (
+	// SamplerRatio samples traces by SampleFraction of trace id.
+	SamplerRatio = "ratio"
+	// SamplerAlwaysOn samples every trace.
+	SamplerAlwaysOn = "always_on"
+	// SamplerAlwaysOff samples no trace.
+	SamplerAlwaysOff = "always_off"
+	// SamplerParentRatio respects the parent sampling decision and samples root traces by SampleFraction.
+	SamplerParentRatio = "parent_ratio"
+)
+
+// sampler returns the trace sampler of SamplerType.
+func (c *TraceProviderConfig) sampler() (sdktrace.Sampler, error) {
+	switch c.SamplerType {
+	case "", SamplerRatio:
+		return sdktrace.TraceIDRatioBased(c.SampleFraction), nil
+	case SamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case SamplerParentRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleFraction)), nil
+	}
+	return nil, fmt.Errorf("otel trace provider unknown sampler type: %q", c.SamplerType)
+}
+
+// traceProviderFlags holds the flag values registered by FromFlags.
+type traceProviderFlags struct {
+	endpoint, name, version, namespace, samplerType *string
+	insecure                                        *bool
+	sampleFraction                                  *float64
+}
+
+// FromFlags registers otel flags on fs, the flags explicitly set on the command line
+// take precedence over environment variables when NewTraceProvider is called.
+func (c *TraceProviderConfig) FromFlags(fs *flag.FlagSet) {
+	c.flagSet = fs
+	c.flags = traceProviderFlags{
+		endpoint:       fs.String("otel-endpoint", c.Endpoint, "otel exporter otlp endpoint"),
+		name:           fs.String("otel-name", c.Name, "otel service name"),
+		version:        fs.String("otel-version", c.Version, "otel service version"),
+		namespace:      fs.String("otel-namespace", c.Namespace, "otel service namespace"),
+		insecure:       fs.Bool("otel-insecure", c.Insecure, "otel exporter without tls"),
+		sampleFraction: fs.Float64("otel-sample-fraction", c.SampleFraction, "otel trace sample fraction"),
+		samplerType:    fs.String("otel-sampler", c.SamplerType, "otel trace sampler type"),
+	}
+}
+
+// fromFlagValues overlays config with the flags explicitly set on the flag set.
+func (c *TraceProviderConfig) fromFlagValues() {
+	if c.flagSet == nil {
+		return
+	}
+	c.flagSet.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "otel-endpoint":
+			c.Endpoint = *c.flags.endpoint
+		case "otel-name":
+			c.Name = *c.flags.name
+		case "otel-version":
+			c.Version = *c.flags.version
+		case "otel-namespace":
+			c.Namespace = *c.flags.namespace
+		case "otel-insecure":
+			c.Insecure = *c.flags.insecure
+		case "otel-sample-fraction":
+			c.SampleFraction = *c.flags.sampleFraction
+		case "otel-sampler":
+			c.SamplerType = *c.flags.samplerType
+		}
+	})
 }
 
 // FromEnv load config from env.
@@ -48,9 +133,15 @@ type TraceProviderImpl struct{}
 func NewTraceProvider(c *TraceProviderConfig) (
 	TraceProvider, func(), error,
 ) {
+	c.FromEnv()
+	c.fromFlagValues()
 	if c.Name == "" || c.Version == "" || c.Endpoint == "" {
 		return nil, nil, fmt.Errorf("otel trace provider config name, version, endpoint must not be empty")
 	}
+	sampler, err := c.sampler()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var exportGrpcOptions []otlptracegrpc.Option
 	if c.Insecure {
@@ -81,10 +172,13 @@ func NewTraceProvider(c *TraceProviderConfig) (
 
 	otel.SetTracerProvider(
 		sdktrace.NewTracerProvider(
-			sdktrace.WithSampler(sdktrace.TraceIDRatioBased(c.SampleFraction)),
+			sdktrace.WithSampler(sampler),
 			sdktrace.WithBatcher(exporter),
 			sdktrace.WithResource(resource.NewSchemaless(attrs...)),
 		),
 	)
+	if c.Propagator != nil {
+		otel.SetTextMapPropagator(c.Propagator)
+	}
 	return &TraceProviderImpl{}, func() {}, nil
 }
@@ -0,0 +1,93 @@
+package otel
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestTraceProviderConfig_FromFlags(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://env:4317")
+
+	c := &TraceProviderConfig{Name: "config", SampleFraction: 0.5}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.FromFlags(fs)
+	if err := fs.Parse([]string{"--otel-name=flag", "--otel-insecure"}); err != nil {
+		t.Fatal(err)
+	}
+	c.FromEnv()
+	c.fromFlagValues()
+
+	if c.Name != "flag" || !c.Insecure {
+		t.Fatalf("expected flags to be applied, got %+v", c)
+	}
+	if c.Endpoint != "env:4317" {
+		t.Fatalf("expected endpoint from env, got %q", c.Endpoint)
+	}
+	if c.SampleFraction != 0.5 {
+		t.Fatalf("expected unset flag to keep config value, got %v", c.SampleFraction)
+	}
+}
+
+func TestTraceProviderConfig_Sampler(t *testing.T) {
+	tests := []struct {
+		samplerType string
+		description string
+	}{
+		{"", "TraceIDRatioBased{0.5}"},
+		{SamplerRatio, "TraceIDRatioBased{0.5}"},
+		{SamplerAlwaysOn, "AlwaysOnSampler"},
+		{SamplerAlwaysOff, "AlwaysOffSampler"},
+		{SamplerParentRatio, "ParentBased{root:TraceIDRatioBased{0.5},remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.samplerType, func(t *testing.T) {
+			c := &TraceProviderConfig{SampleFraction: 0.5, SamplerType: tt.samplerType}
+			sampler, err := c.sampler()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sampler.Description(); got != tt.description {
+				t.Fatalf("expected sampler %s, got %s", tt.description, got)
+			}
+		})
+	}
+
+	if _, err := (&TraceProviderConfig{SamplerType: "rate_limiting"}).sampler(); err == nil {
+		t.Fatal("expected error for unknown sampler type")
+	}
+}
+
+func TestNewTraceProvider_Propagator(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	originPropagator, originProvider := otel.GetTextMapPropagator(), otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(originPropagator)
+		otel.SetTracerProvider(originProvider)
+	})
+	otel.SetTextMapPropagator(propagation.Baggage{})
+
+	newConfig := func() *TraceProviderConfig {
+		return &TraceProviderConfig{
+			Context: context.Background(), Name: "test", Version: "v1", Endpoint: "localhost:4317", Insecure: true,
+		}
+	}
+	if _, _, err := NewTraceProvider(newConfig()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := otel.GetTextMapPropagator().(propagation.Baggage); !ok {
+		t.Fatalf("expected global propagator to be untouched, got %T", otel.GetTextMapPropagator())
+	}
+
+	c := newConfig()
+	c.Propagator = propagation.TraceContext{}
+	if _, _, err := NewTraceProvider(c); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := otel.GetTextMapPropagator().(propagation.TraceContext); !ok {
+		t.Fatalf("expected global propagator to be set, got %T", otel.GetTextMapPropagator())
+	}
+}
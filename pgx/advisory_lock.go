@@ -0,0 +1,62 @@
+package pgx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrAdvisoryLockNotAcquired is returned when the advisory lock is held by another session.
+var ErrAdvisoryLockNotAcquired = errors.New("advisory lock not acquired")
+
+// AdvisoryLock tries to acquire the postgres session level advisory lock for key without waiting.
+// advisory locks belong to a session, so a dedicated connection is held until unlock is called.
+func AdvisoryLock(db *sql.DB, key int64) (unlock func() error, err error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("advisory lock get connection: %w", err)
+	}
+	var acquired bool
+	if err = conn.QueryRowContext(ctx, "select pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("advisory lock: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, fmt.Errorf("advisory lock %d: %w", key, ErrAdvisoryLockNotAcquired)
+	}
+	return advisoryUnlock(conn, key), nil
+}
+
+// AdvisoryLockWait acquires the postgres session level advisory lock for key,
+// it blocks until the lock is acquired or ctx is cancelled.
+func AdvisoryLockWait(ctx context.Context, db *sql.DB, key int64) (unlock func() error, err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("advisory lock get connection: %w", err)
+	}
+	if _, err = conn.ExecContext(ctx, "select pg_advisory_lock($1)", key); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("advisory lock wait: %w", err)
+	}
+	return advisoryUnlock(conn, key), nil
+}
+
+// advisoryUnlock returns a function that releases the advisory lock and the held connection.
+func advisoryUnlock(conn *sql.Conn, key int64) func() error {
+	return func() error {
+		defer func() { _ = conn.Close() }()
+		var released bool
+		err := conn.QueryRowContext(context.Background(), "select pg_advisory_unlock($1)", key).
+			Scan(&released)
+		if err != nil {
+			return fmt.Errorf("advisory unlock: %w", err)
+		}
+		if !released {
+			return fmt.Errorf("advisory unlock %d: lock was not held", key)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package pgx
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+// ConnStringBuilder builds postgres:// connection strings with properly escaped fields.
+type ConnStringBuilder struct {
+	Host     string
+	Port     string
+	Database string
+	User     string
+	Password string
+	SSLMode  string
+	AppName  string
+
+	params url.Values
+}
+
+// AddParam adds an extra connection parameter.
+func (b *ConnStringBuilder) AddParam(key, value string) *ConnStringBuilder {
+	if b.params == nil {
+		b.params = make(url.Values)
+	}
+	b.params.Add(key, value)
+	return b
+}
+
+// Build validates the fields and returns the connection string.
+func (b *ConnStringBuilder) Build() (string, error) {
+	if b.Host == "" {
+		return "", fmt.Errorf("conn string: host must not be empty")
+	}
+	host := b.Host
+	if b.Port != "" {
+		port, err := strconv.Atoi(b.Port)
+		if err != nil || port < 1 || port > 65535 {
+			return "", fmt.Errorf("conn string: port must be in range 1-65535: %q", b.Port)
+		}
+		host = net.JoinHostPort(b.Host, b.Port)
+	}
+
+	out := url.URL{Scheme: "postgres", Host: host}
+	if b.User != "" {
+		if b.Password != "" {
+			out.User = url.UserPassword(b.User, b.Password)
+		} else {
+			out.User = url.User(b.User)
+		}
+	}
+	if b.Database != "" {
+		out.Path = "/" + b.Database
+	}
+
+	query := make(url.Values, len(b.params)+2)
+	for key, values := range b.params {
+		query[key] = append([]string(nil), values...)
+	}
+	if b.SSLMode != "" {
+		query.Set("sslmode", b.SSLMode)
+	}
+	if b.AppName != "" {
+		query.Set("application_name", b.AppName)
+	}
+	out.RawQuery = query.Encode()
+	return out.String(), nil
+}
@@ -0,0 +1,120 @@
+package pgx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ driver.Valuer = PointWrapper{}
+	_ sql.Scanner   = &PointWrapper{}
+	_ driver.Valuer = LineWrapper{}
+	_ sql.Scanner   = &LineWrapper{}
+)
+
+// geometryText returns the text representation of a postgres geometric value.
+func geometryText(src interface{}) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return strings.TrimSpace(src), nil
+	case []byte:
+		return strings.TrimSpace(string(src)), nil
+	}
+	return "", fmt.Errorf("geometry scan: unable to scan %T", src)
+}
+
+// parseFloats parses the comma separated float values of a geometric value.
+func parseFloats(text string, n int) ([]float64, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("geometry scan: expected %d values, got %q", n, text)
+	}
+	out := make([]float64, 0, n)
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("geometry scan: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// formatFloat formats a float value for postgres geometric types.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// PointWrapper is a wrapper for postgres point type.
+type PointWrapper struct {
+	X, Y float64
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w PointWrapper) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%s,%s)", formatFloat(w.X), formatFloat(w.Y)), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+// it accepts both "(x,y)" and "x,y" text representations.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *PointWrapper) Scan(src interface{}) error {
+	if src == nil {
+		*w = PointWrapper{}
+		return nil
+	}
+	text, err := geometryText(src)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		text = text[1 : len(text)-1]
+	}
+	values, err := parseFloats(text, 2)
+	if err != nil {
+		return err
+	}
+	w.X, w.Y = values[0], values[1]
+	return nil
+}
+
+// LineWrapper is a wrapper for postgres line type represented by the equation Ax + By + C = 0.
+type LineWrapper struct {
+	A, B, C float64
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w LineWrapper) Value() (driver.Value, error) {
+	return fmt.Sprintf("{%s,%s,%s}", formatFloat(w.A), formatFloat(w.B), formatFloat(w.C)), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *LineWrapper) Scan(src interface{}) error {
+	if src == nil {
+		*w = LineWrapper{}
+		return nil
+	}
+	text, err := geometryText(src)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return fmt.Errorf("geometry scan: invalid line %q", text)
+	}
+	values, err := parseFloats(text[1:len(text)-1], 3)
+	if err != nil {
+		return err
+	}
+	w.A, w.B, w.C = values[0], values[1], values[2]
+	return nil
+}
@@ -3,8 +3,10 @@ package pgx
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"net/netip"
+	"reflect"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -143,30 +145,343 @@ func (w *TimestampsWrapper) Scan(src interface{}) error {
 	return (*SliceWrapper[time.Time])(w).Scan(src)
 }
 
+// defined wrapper types do not inherit methods, so json methods are declared for each of them.
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRWrapper) MarshalJSON() ([]byte, error) { return StdWrapper[netip.Prefix](w).MarshalJSON() }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *CIDRWrapper) UnmarshalJSON(data []byte) error {
+	return (*StdWrapper[netip.Prefix])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationWrapper) MarshalJSON() ([]byte, error) {
+	return StdWrapper[time.Duration](w).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *DurationWrapper) UnmarshalJSON(data []byte) error {
+	return (*StdWrapper[time.Duration])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w IntsWrapper) MarshalJSON() ([]byte, error) { return SliceWrapper[int](w).MarshalJSON() }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *IntsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[int])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w FloatsWrapper) MarshalJSON() ([]byte, error) { return SliceWrapper[float64](w).MarshalJSON() }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *FloatsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[float64])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StringsWrapper) MarshalJSON() ([]byte, error) { return SliceWrapper[string](w).MarshalJSON() }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *StringsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[string])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRsWrapper) MarshalJSON() ([]byte, error) {
+	return SliceWrapper[netip.Prefix](w).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *CIDRsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[netip.Prefix])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationsWrapper) MarshalJSON() ([]byte, error) {
+	return SliceWrapper[time.Duration](w).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *DurationsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[time.Duration])(w).UnmarshalJSON(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TimestampsWrapper) MarshalJSON() ([]byte, error) {
+	return SliceWrapper[time.Time](w).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[time.Time])(w).UnmarshalJSON(data)
+}
+
+// defined wrapper types do not inherit methods, so accessors are declared for each of them.
+
+// ToValue returns the underlying value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRWrapper) ToValue() netip.Prefix { return w.V }
+
+// IsZero reports whether V is the zero value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRWrapper) IsZero() bool { return StdWrapper[netip.Prefix](w).IsZero() }
+
+// IsNull reports whether the wrapper holds a NULL.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRWrapper) IsNull() bool { return StdWrapper[netip.Prefix](w).IsNull() }
+
+// ToValue returns the underlying value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationWrapper) ToValue() time.Duration { return w.V }
+
+// IsZero reports whether V is the zero value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationWrapper) IsZero() bool { return StdWrapper[time.Duration](w).IsZero() }
+
+// IsNull reports whether the wrapper holds a NULL.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationWrapper) IsNull() bool { return StdWrapper[time.Duration](w).IsNull() }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w IntsWrapper) ToSlice() []int { return w.V }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w FloatsWrapper) ToSlice() []float64 { return w.V }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StringsWrapper) ToSlice() []string { return w.V }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w CIDRsWrapper) ToSlice() []netip.Prefix { return w.V }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w DurationsWrapper) ToSlice() []time.Duration { return w.V }
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TimestampsWrapper) ToSlice() []time.Time { return w.V }
+
+// Filter returns a new IntsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *IntsWrapper) Filter(fn func(int) bool) *IntsWrapper {
+	return (*IntsWrapper)((*SliceWrapper[int])(w).Filter(fn))
+}
+
+// Map returns a new IntsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *IntsWrapper) Map(fn func(int) int) *IntsWrapper {
+	return (*IntsWrapper)((*SliceWrapper[int])(w).Map(fn))
+}
+
+// Filter returns a new FloatsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *FloatsWrapper) Filter(fn func(float64) bool) *FloatsWrapper {
+	return (*FloatsWrapper)((*SliceWrapper[float64])(w).Filter(fn))
+}
+
+// Map returns a new FloatsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *FloatsWrapper) Map(fn func(float64) float64) *FloatsWrapper {
+	return (*FloatsWrapper)((*SliceWrapper[float64])(w).Map(fn))
+}
+
+// Filter returns a new StringsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *StringsWrapper) Filter(fn func(string) bool) *StringsWrapper {
+	return (*StringsWrapper)((*SliceWrapper[string])(w).Filter(fn))
+}
+
+// Map returns a new StringsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *StringsWrapper) Map(fn func(string) string) *StringsWrapper {
+	return (*StringsWrapper)((*SliceWrapper[string])(w).Map(fn))
+}
+
+// Filter returns a new CIDRsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *CIDRsWrapper) Filter(fn func(netip.Prefix) bool) *CIDRsWrapper {
+	return (*CIDRsWrapper)((*SliceWrapper[netip.Prefix])(w).Filter(fn))
+}
+
+// Map returns a new CIDRsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *CIDRsWrapper) Map(fn func(netip.Prefix) netip.Prefix) *CIDRsWrapper {
+	return (*CIDRsWrapper)((*SliceWrapper[netip.Prefix])(w).Map(fn))
+}
+
+// Filter returns a new DurationsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *DurationsWrapper) Filter(fn func(time.Duration) bool) *DurationsWrapper {
+	return (*DurationsWrapper)((*SliceWrapper[time.Duration])(w).Filter(fn))
+}
+
+// Map returns a new DurationsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *DurationsWrapper) Map(fn func(time.Duration) time.Duration) *DurationsWrapper {
+	return (*DurationsWrapper)((*SliceWrapper[time.Duration])(w).Map(fn))
+}
+
+// Filter returns a new TimestampsWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsWrapper) Filter(fn func(time.Time) bool) *TimestampsWrapper {
+	return (*TimestampsWrapper)((*SliceWrapper[time.Time])(w).Filter(fn))
+}
+
+// Map returns a new TimestampsWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsWrapper) Map(fn func(time.Time) time.Time) *TimestampsWrapper {
+	return (*TimestampsWrapper)((*SliceWrapper[time.Time])(w).Map(fn))
+}
+
 var (
-	_ driver.Valuer = StdWrapper[netip.Prefix]{}
-	_ sql.Scanner   = &StdWrapper[netip.Prefix]{}
+	_ driver.Valuer    = StdWrapper[netip.Prefix]{}
+	_ sql.Scanner      = &StdWrapper[netip.Prefix]{}
+	_ json.Marshaler   = SliceWrapper[string]{}
+	_ json.Unmarshaler = &SliceWrapper[string]{}
 
 	typeMap = pgtype.NewMap()
 )
 
 // StdWrapper is a wrapper for pgx standard sql library types.
+// a NULL scanned or unmarshaled into it is reported by IsNull and written back as NULL.
 type StdWrapper[T any] struct {
 	V T
+
+	null bool
 }
 
 // Value implements the database/sql/driver Valuer interface.
 //
 //goland:noinspection GoMixedReceiverTypes
 func (w StdWrapper[T]) Value() (driver.Value, error) {
+	if w.null {
+		return nil, nil
+	}
 	return w.V, nil
 }
 
 // Scan implements the database/sql Scanner interface.
+// NULL resets V to its zero value.
 //
 //goland:noinspection GoMixedReceiverTypes
 func (w *StdWrapper[T]) Scan(src interface{}) (err error) {
-	return typeMapScan(src, &w.V)
+	if src == nil {
+		var zero T
+		w.V, w.null = zero, true
+		return nil
+	}
+	if err = typeMapScan(src, &w.V); err != nil {
+		return err
+	}
+	w.null = false
+	return nil
+}
+
+// IsZero reports whether V is the zero value, use IsNull to tell a NULL from a zero value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StdWrapper[T]) IsZero() bool {
+	return reflect.ValueOf(&w.V).Elem().IsZero()
+}
+
+// IsNull reports whether the wrapper holds a NULL from Scan or a null from UnmarshalJSON.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StdWrapper[T]) IsNull() bool {
+	return w.null
+}
+
+// MarshalJSON implements the json.Marshaler interface, it marshals V directly and NULL as null.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StdWrapper[T]) MarshalJSON() ([]byte, error) {
+	if w.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it unmarshals into V directly.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *StdWrapper[T]) UnmarshalJSON(data []byte) error {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	w.V, w.null = v, string(data) == "null"
+	return nil
+}
+
+// ToValue returns the underlying value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w StdWrapper[T]) ToValue() T {
+	return w.V
 }
 
 // SliceWrapper is a wrapper for pgx standard sql library types.
@@ -192,6 +507,55 @@ func (w *SliceWrapper[T]) Scan(src interface{}) (err error) {
 	return typeMapScan(src, &w.V)
 }
 
+// MarshalJSON implements the json.Marshaler interface, it marshals V directly.
+// nil slice is marshaled as an empty array.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w SliceWrapper[T]) MarshalJSON() ([]byte, error) {
+	if w.V == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(w.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, it unmarshals into V directly.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *SliceWrapper[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &w.V)
+}
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w SliceWrapper[T]) ToSlice() []T {
+	return w.V
+}
+
+// Filter returns a new SliceWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *SliceWrapper[T]) Filter(fn func(T) bool) *SliceWrapper[T] {
+	out := &SliceWrapper[T]{V: make([]T, 0, len(w.V))}
+	for _, v := range w.V {
+		if fn(v) {
+			out.V = append(out.V, v)
+		}
+	}
+	return out
+}
+
+// Map returns a new SliceWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *SliceWrapper[T]) Map(fn func(T) T) *SliceWrapper[T] {
+	out := &SliceWrapper[T]{V: make([]T, 0, len(w.V))}
+	for _, v := range w.V {
+		out.V = append(out.V, fn(v))
+	}
+	return out
+}
+
 // typeMapScan is a workaround for pgx standard sql library types.
 func typeMapScan[T any](src interface{}, target *T) (err error) {
 	var value T
@@ -231,7 +595,7 @@ func guessingScan[T any](src interface{}) (value T, err error) {
 		}
 		return plan.Scan(bufSrc, &value)
 	}
-	formats := []int16{pgtype.TextFormatCode}
+	formats := []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode}
 	guessTypes := []uint32{
 		pgtype.JSONBOID,
 		pgtype.JSONOID,
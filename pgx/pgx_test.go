@@ -1,12 +1,17 @@
 package pgx
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/netip"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ory/dockertest/v3/docker"
 
@@ -150,3 +155,200 @@ func TestPGXNetPrefixArray(t *testing.T) {
 		}
 	}
 }
+
+func TestAdvisoryLock(t *testing.T) {
+	unlock, err := AdvisoryLock(db, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = AdvisoryLock(db, 42); !errors.Is(err, ErrAdvisoryLockNotAcquired) {
+		t.Fatalf("Expected %v, got %v", ErrAdvisoryLockNotAcquired, err)
+	}
+	if err = unlock(); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = AdvisoryLockWait(context.Background(), db, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPGXInt4Range(t *testing.T) {
+	input := Int4RangeWrapper{Lower: 1, Upper: 10, LowerInclusive: true}
+	var output Int4RangeWrapper
+	if err := db.QueryRow("select $1::int4range", input).Scan(&output); err != nil {
+		t.Fatal(err)
+	}
+	if output != input {
+		t.Fatalf("Expected %v, got %v", input, output)
+	}
+	if err := db.QueryRow("select 'empty'::int4range").Scan(&output); err != nil {
+		t.Fatal(err)
+	}
+	if !output.Empty {
+		t.Fatalf("Expected empty range, got %v", output)
+	}
+}
+
+func TestPGXTstzRange(t *testing.T) {
+	lower := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := TstzRangeWrapper{Lower: lower, LowerInclusive: true, UpperUnbounded: true}
+	var output TstzRangeWrapper
+	if err := db.QueryRow("select $1::tstzrange", input).Scan(&output); err != nil {
+		t.Fatal(err)
+	}
+	if !output.Lower.Equal(lower) || !output.LowerInclusive || !output.UpperUnbounded {
+		t.Fatalf("Expected %v, got %v", input, output)
+	}
+}
+
+func TestPGXPoint(t *testing.T) {
+	input := PointWrapper{X: 1.5, Y: -2}
+	var output PointWrapper
+	if err := db.QueryRow("select $1::point", input).Scan(&output); err != nil {
+		t.Fatal(err)
+	}
+	if output != input {
+		t.Fatalf("Expected %v, got %v", input, output)
+	}
+}
+
+func TestWrapperJSON(t *testing.T) {
+	type response struct {
+		Tags     StringsWrapper  `json:"tags"`
+		Empty    IntsWrapper     `json:"empty"`
+		Duration DurationWrapper `json:"duration"`
+	}
+	in := response{Tags: StringsWrapper{V: []string{"a", "b"}}, Duration: DurationWrapper{V: time.Second}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"tags":["a","b"],"empty":[],"duration":1000000000}` {
+		t.Fatalf("unexpected json: %s", data)
+	}
+	var out response
+	if err = json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Tags.V) != 2 || out.Tags.V[1] != "b" || out.Duration.V != time.Second {
+		t.Fatalf("unexpected unmarshaled value: %+v", out)
+	}
+}
+
+func TestStdWrapperIsZero(t *testing.T) {
+	if (DurationWrapper{V: time.Second}).IsZero() {
+		t.Fatal("expected wrapper built with a value to be non-zero")
+	}
+	var w StdWrapper[string]
+	if !w.IsZero() || w.IsNull() {
+		t.Fatalf("expected zero non-NULL wrapper before scan, got %+v", w)
+	}
+	if err := w.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsZero() || !w.IsNull() {
+		t.Fatalf("expected NULL wrapper after scanning NULL, got %+v", w)
+	}
+	if v, err := w.Value(); err != nil || v != nil {
+		t.Fatalf("expected NULL to be written back as NULL, got %v %v", v, err)
+	}
+	if err := w.Scan(""); err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsZero() || w.IsNull() {
+		t.Fatalf("expected scanned empty string to be zero but not NULL, got %+v", w)
+	}
+	if v, err := w.Value(); err != nil || v != "" {
+		t.Fatalf("expected empty string to be written back, got %v %v", v, err)
+	}
+
+	var duration DurationWrapper
+	if err := db.QueryRow("select '0'::interval").Scan(&duration); err != nil {
+		t.Fatal(err)
+	}
+	if !duration.IsZero() || duration.IsNull() {
+		t.Fatalf("expected zero interval not to be NULL, got %+v", duration)
+	}
+	if err := db.QueryRow("select null::interval").Scan(&duration); err != nil {
+		t.Fatal(err)
+	}
+	if !duration.IsNull() {
+		t.Fatalf("expected NULL interval, got %+v", duration)
+	}
+	var isNull bool
+	if err := db.QueryRow("select $1::interval is null", duration).Scan(&isNull); err != nil || !isNull {
+		t.Fatalf("expected NULL interval to round trip, got %v %v", isNull, err)
+	}
+
+	var cidr CIDRWrapper
+	if err := json.Unmarshal([]byte("null"), &cidr); err != nil || !cidr.IsNull() {
+		t.Fatalf("expected JSON null to be NULL, got %+v %v", cidr, err)
+	}
+	if data, err := json.Marshal(StdWrapper[netip.Prefix](cidr)); err != nil || string(data) != "null" {
+		t.Fatalf("expected NULL to marshal as null, got %s %v", data, err)
+	}
+}
+
+func TestPGXUTCTime(t *testing.T) {
+	var output UTCTimeWrapper
+	if err := db.QueryRow("select '2024-01-01 08:00:00+08'::timestamptz").Scan(&output); err != nil {
+		t.Fatal(err)
+	}
+	if output.V.Location() != time.UTC || !output.V.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected UTC time, got %v", output.V)
+	}
+
+	times := NewTimestampsWrapperUTC()
+	if err := db.QueryRow("select array['2024-01-01 08:00:00+08']::timestamptz[]").Scan(&times); err != nil {
+		t.Fatal(err)
+	}
+	if len(times.V) != 1 || times.V[0].Location() != time.UTC || times.V[0].Hour() != 0 {
+		t.Fatalf("Expected UTC times, got %v", times.V)
+	}
+}
+
+func TestWrapperAccessors(t *testing.T) {
+	strs := StringsWrapper{V: []string{"a", "b"}}
+	if got := strs.ToSlice(); len(got) != 2 || got[1] != "b" {
+		t.Fatalf("unexpected ToSlice result: %v", got)
+	}
+	ints := NewIntsWrapper()
+	if got := ints.ToSlice(); got == nil || len(got) != 0 {
+		t.Fatalf("unexpected ToSlice result: %v", got)
+	}
+	duration := DurationWrapper{V: time.Second}
+	if duration.ToValue() != time.Second {
+		t.Fatalf("unexpected ToValue result: %v", duration.ToValue())
+	}
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+	if cidr := (CIDRWrapper{V: prefix}); cidr.ToValue() != prefix {
+		t.Fatalf("unexpected ToValue result: %v", cidr.ToValue())
+	}
+}
+
+func TestWrapperFilterMap(t *testing.T) {
+	strs := StringsWrapper{V: []string{"a", "bb", "ccc"}}
+	long := strs.Filter(func(s string) bool { return len(s) > 1 })
+	if got := long.ToSlice(); len(got) != 2 || got[0] != "bb" {
+		t.Fatalf("unexpected Filter result: %v", got)
+	}
+	upper := strs.Map(strings.ToUpper)
+	if got := upper.ToSlice(); len(got) != 3 || got[2] != "CCC" || strs.V[2] != "ccc" {
+		t.Fatalf("unexpected Map result: %v", got)
+	}
+
+	ints := IntsWrapper{V: []int{1, 2, 3}}
+	doubled := ints.Filter(func(v int) bool { return v%2 == 1 }).Map(func(v int) int { return v * 2 })
+	if got := doubled.ToSlice(); len(got) != 2 || got[0] != 2 || got[1] != 6 {
+		t.Fatalf("unexpected Filter and Map result: %v", got)
+	}
+
+	empty := (&SliceWrapper[string]{}).Filter(func(string) bool { return true })
+	if empty.V == nil || len(empty.V) != 0 {
+		t.Fatalf("expected empty non-nil slice, got %v", empty.V)
+	}
+}
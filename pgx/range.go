@@ -0,0 +1,270 @@
+package pgx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	_ driver.Valuer = Int4RangeWrapper{}
+	_ sql.Scanner   = &Int4RangeWrapper{}
+	_ driver.Valuer = TstzRangeWrapper{}
+	_ sql.Scanner   = &TstzRangeWrapper{}
+)
+
+// rangeEmpty is the postgres text representation of an empty range.
+const rangeEmpty = "empty"
+
+// rangeText is the parsed text representation of a postgres range.
+type rangeText struct {
+	Lower, Upper                   string
+	LowerInclusive, UpperInclusive bool
+	LowerUnbounded, UpperUnbounded bool
+	Empty                          bool
+}
+
+// String formats the range as postgres range literal, e.g. [lower,upper).
+func (r rangeText) String() string {
+	if r.Empty {
+		return rangeEmpty
+	}
+	var b strings.Builder
+	if r.LowerInclusive && !r.LowerUnbounded {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if !r.LowerUnbounded {
+		b.WriteString(strconv.Quote(r.Lower))
+	}
+	b.WriteByte(',')
+	if !r.UpperUnbounded {
+		b.WriteString(strconv.Quote(r.Upper))
+	}
+	if r.UpperInclusive && !r.UpperUnbounded {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// parseRangeText parses the postgres range text representation.
+func parseRangeText(src interface{}) (out rangeText, err error) {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return out, fmt.Errorf("range scan: unable to scan %T", src)
+	}
+	text = strings.TrimSpace(text)
+	if strings.EqualFold(text, rangeEmpty) {
+		out.Empty = true
+		return out, nil
+	}
+	if len(text) < 3 {
+		return out, fmt.Errorf("range scan: invalid range %q", text)
+	}
+	switch text[0] {
+	case '[':
+		out.LowerInclusive = true
+	case '(':
+	default:
+		return out, fmt.Errorf("range scan: invalid lower bound %q", text)
+	}
+	switch text[len(text)-1] {
+	case ']':
+		out.UpperInclusive = true
+	case ')':
+	default:
+		return out, fmt.Errorf("range scan: invalid upper bound %q", text)
+	}
+
+	inner := text[1 : len(text)-1]
+	inQuote, sep := false, -1
+	for i := 0; i < len(inner) && sep < 0; i++ {
+		switch inner[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuote = !inQuote
+		case ',':
+			if !inQuote {
+				sep = i
+			}
+		}
+	}
+	if sep < 0 {
+		return out, fmt.Errorf("range scan: missing bounds separator %q", text)
+	}
+	out.Lower, out.LowerUnbounded = unquoteRangeBound(inner[:sep])
+	out.Upper, out.UpperUnbounded = unquoteRangeBound(inner[sep+1:])
+	return out, nil
+}
+
+// unquoteRangeBound unquotes a range bound, an empty unquoted bound is unbounded.
+func unquoteRangeBound(bound string) (value string, unbounded bool) {
+	if bound == "" {
+		return "", true
+	}
+	if len(bound) >= 2 && bound[0] == '"' && bound[len(bound)-1] == '"' {
+		bound = bound[1 : len(bound)-1]
+		bound = strings.ReplaceAll(bound, `""`, `"`)
+		bound = strings.ReplaceAll(bound, `\"`, `"`)
+		bound = strings.ReplaceAll(bound, `\\`, `\`)
+	}
+	return bound, false
+}
+
+// Int4RangeWrapper is a wrapper for postgres int4range type.
+type Int4RangeWrapper struct {
+	Lower, Upper                   int32
+	LowerInclusive, UpperInclusive bool
+	// LowerUnbounded and UpperUnbounded mark infinite endpoints, the bound value is ignored.
+	LowerUnbounded, UpperUnbounded bool
+	// Empty marks the empty range, all other fields are ignored.
+	Empty bool
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w Int4RangeWrapper) Value() (driver.Value, error) {
+	return rangeText{
+		Lower:          strconv.FormatInt(int64(w.Lower), 10),
+		Upper:          strconv.FormatInt(int64(w.Upper), 10),
+		LowerInclusive: w.LowerInclusive,
+		UpperInclusive: w.UpperInclusive,
+		LowerUnbounded: w.LowerUnbounded,
+		UpperUnbounded: w.UpperUnbounded,
+		Empty:          w.Empty,
+	}.String(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *Int4RangeWrapper) Scan(src interface{}) error {
+	if src == nil {
+		*w = Int4RangeWrapper{}
+		return nil
+	}
+	r, err := parseRangeText(src)
+	if err != nil {
+		return err
+	}
+	out := Int4RangeWrapper{
+		LowerInclusive: r.LowerInclusive,
+		UpperInclusive: r.UpperInclusive,
+		LowerUnbounded: r.LowerUnbounded,
+		UpperUnbounded: r.UpperUnbounded,
+		Empty:          r.Empty,
+	}
+	if !r.Empty && !r.LowerUnbounded {
+		lower, err := strconv.ParseInt(r.Lower, 10, 32)
+		if err != nil {
+			return fmt.Errorf("int4range scan lower: %w", err)
+		}
+		out.Lower = int32(lower)
+	}
+	if !r.Empty && !r.UpperUnbounded {
+		upper, err := strconv.ParseInt(r.Upper, 10, 32)
+		if err != nil {
+			return fmt.Errorf("int4range scan upper: %w", err)
+		}
+		out.Upper = int32(upper)
+	}
+	*w = out
+	return nil
+}
+
+// tstzLayouts are the layouts tried when parsing timestamptz range bounds.
+var tstzLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	time.RFC3339Nano,
+}
+
+// parseTstz parses the postgres timestamptz text representation,
+// infinity and -infinity are reported as unbounded.
+func parseTstz(s string) (t time.Time, unbounded bool, err error) {
+	if s == "infinity" || s == "-infinity" {
+		return t, true, nil
+	}
+	for _, layout := range tstzLayouts {
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, false, nil
+		}
+	}
+	return t, false, fmt.Errorf("tstzrange scan: invalid timestamp %q", s)
+}
+
+// TstzRangeWrapper is a wrapper for postgres tstzrange type.
+type TstzRangeWrapper struct {
+	Lower, Upper                   time.Time
+	LowerInclusive, UpperInclusive bool
+	// LowerUnbounded and UpperUnbounded mark infinite endpoints, the bound value is ignored.
+	LowerUnbounded, UpperUnbounded bool
+	// Empty marks the empty range, all other fields are ignored.
+	Empty bool
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TstzRangeWrapper) Value() (driver.Value, error) {
+	return rangeText{
+		Lower:          w.Lower.Format(time.RFC3339Nano),
+		Upper:          w.Upper.Format(time.RFC3339Nano),
+		LowerInclusive: w.LowerInclusive,
+		UpperInclusive: w.UpperInclusive,
+		LowerUnbounded: w.LowerUnbounded,
+		UpperUnbounded: w.UpperUnbounded,
+		Empty:          w.Empty,
+	}.String(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TstzRangeWrapper) Scan(src interface{}) (err error) {
+	if src == nil {
+		*w = TstzRangeWrapper{}
+		return nil
+	}
+	r, err := parseRangeText(src)
+	if err != nil {
+		return err
+	}
+	out := TstzRangeWrapper{
+		LowerInclusive: r.LowerInclusive,
+		UpperInclusive: r.UpperInclusive,
+		LowerUnbounded: r.LowerUnbounded,
+		UpperUnbounded: r.UpperUnbounded,
+		Empty:          r.Empty,
+	}
+	if !r.Empty && !r.LowerUnbounded {
+		var unbounded bool
+		if out.Lower, unbounded, err = parseTstz(r.Lower); err != nil {
+			return err
+		}
+		out.LowerUnbounded = unbounded
+	}
+	if !r.Empty && !r.UpperUnbounded {
+		var unbounded bool
+		if out.Upper, unbounded, err = parseTstz(r.Upper); err != nil {
+			return err
+		}
+		out.UpperUnbounded = unbounded
+	}
+	*w = out
+	return nil
+}
@@ -0,0 +1,140 @@
+package pgx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+type (
+	// UTCTimeWrapper is a wrapper for time.Time which is always scanned and sent in UTC.
+	UTCTimeWrapper StdWrapper[time.Time]
+	// TimestampsUTCWrapper is a wrapper for time.Time slice which is always scanned and sent in UTC.
+	TimestampsUTCWrapper SliceWrapper[time.Time]
+)
+
+var (
+	_ driver.Valuer = UTCTimeWrapper{}
+	_ sql.Scanner   = &UTCTimeWrapper{}
+	_ driver.Valuer = TimestampsUTCWrapper{}
+	_ sql.Scanner   = &TimestampsUTCWrapper{}
+)
+
+// toUTC converts the times to UTC in place.
+func toUTC(times []time.Time) {
+	for i := range times {
+		times[i] = times[i].UTC()
+	}
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w UTCTimeWrapper) Value() (driver.Value, error) {
+	if w.null {
+		return nil, nil
+	}
+	return w.V.UTC(), nil
+}
+
+// Scan implements the database/sql Scanner interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *UTCTimeWrapper) Scan(src interface{}) error {
+	// pgx stdlib returns time.Time for timestamp columns
+	if t, ok := src.(time.Time); ok {
+		w.V, w.null = t.UTC(), false
+		return nil
+	}
+	if err := (*StdWrapper[time.Time])(w).Scan(src); err != nil {
+		return err
+	}
+	w.V = w.V.UTC()
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w UTCTimeWrapper) MarshalJSON() ([]byte, error) { return StdWrapper[time.Time](w).MarshalJSON() }
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *UTCTimeWrapper) UnmarshalJSON(data []byte) error {
+	return (*StdWrapper[time.Time])(w).UnmarshalJSON(data)
+}
+
+// ToValue returns the underlying value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w UTCTimeWrapper) ToValue() time.Time { return w.V }
+
+// IsZero reports whether V is the zero value.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w UTCTimeWrapper) IsZero() bool { return StdWrapper[time.Time](w).IsZero() }
+
+// IsNull reports whether the wrapper holds a NULL.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w UTCTimeWrapper) IsNull() bool { return StdWrapper[time.Time](w).IsNull() }
+
+// NewTimestampsWrapperUTC returns a new TimestampsUTCWrapper.
+func NewTimestampsWrapperUTC() TimestampsUTCWrapper {
+	return TimestampsUTCWrapper{V: make([]time.Time, 0)}
+}
+
+// Value implements the database/sql/driver Valuer interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TimestampsUTCWrapper) Value() (driver.Value, error) {
+	out := make([]time.Time, len(w.V))
+	copy(out, w.V)
+	toUTC(out)
+	return SliceWrapper[time.Time]{V: out}.Value()
+}
+
+// Scan implements the database/sql Scanner interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsUTCWrapper) Scan(src interface{}) error {
+	if err := (*SliceWrapper[time.Time])(w).Scan(src); err != nil {
+		return err
+	}
+	toUTC(w.V)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TimestampsUTCWrapper) MarshalJSON() ([]byte, error) {
+	return SliceWrapper[time.Time](w).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsUTCWrapper) UnmarshalJSON(data []byte) error {
+	return (*SliceWrapper[time.Time])(w).UnmarshalJSON(data)
+}
+
+// ToSlice returns the underlying slice.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w TimestampsUTCWrapper) ToSlice() []time.Time { return w.V }
+
+// Filter returns a new TimestampsUTCWrapper containing only the elements where fn returns true.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsUTCWrapper) Filter(fn func(time.Time) bool) *TimestampsUTCWrapper {
+	return (*TimestampsUTCWrapper)((*SliceWrapper[time.Time])(w).Filter(fn))
+}
+
+// Map returns a new TimestampsUTCWrapper containing the elements transformed by fn.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (w *TimestampsUTCWrapper) Map(fn func(time.Time) time.Time) *TimestampsUTCWrapper {
+	return (*TimestampsUTCWrapper)((*SliceWrapper[time.Time])(w).Map(fn))
+}
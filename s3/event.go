@@ -2,7 +2,10 @@ package s3
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -119,3 +122,67 @@ type Source struct {
 	Port      string `json:"port"`
 	UserAgent string `json:"userAgent"`
 }
+
+// ParseEvent parses an Event from a webhook payload
+func ParseEvent(body []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+	return &event, nil
+}
+
+// ValidateEvent checks the required fields of an Event
+func ValidateEvent(event *Event) error {
+	switch {
+	case event == nil:
+		return errors.New("event is nil")
+	case event.EventName == "":
+		return errors.New("event name is empty")
+	case event.Key == "":
+		return errors.New("event key is empty")
+	case len(event.Records) == 0:
+		return errors.New("event records are empty")
+	}
+	for i, record := range event.Records {
+		if record.EventTime.IsZero() {
+			return fmt.Errorf("event record %d time is zero", i)
+		}
+	}
+	return nil
+}
+
+// EventMatcher matches events by event names, names ending with * match the whole event group
+type EventMatcher []EventName
+
+// Match reports whether the event name matches any of the matcher event names
+func (m EventMatcher) Match(event *Event) bool {
+	if event == nil {
+		return false
+	}
+	for _, name := range m {
+		if MatchEventName(name, event.EventName) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchEventName reports whether event matches pattern, pattern supports * wildcard at the end only
+func MatchEventName(pattern, event EventName) bool {
+	if prefix, ok := strings.CutSuffix(string(pattern), "*"); ok {
+		return strings.HasPrefix(string(event), prefix)
+	}
+	return pattern == event
+}
+
+// FilterRecords returns the records whose event name matches pattern
+func FilterRecords(records []EventRecord, pattern EventName) []EventRecord {
+	out := make([]EventRecord, 0, len(records))
+	for _, record := range records {
+		if MatchEventName(pattern, record.EventName) {
+			out = append(out, record)
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const eventPayload = `{
+  "EventName": "s3:ObjectCreated:Put",
+  "Key": "bucket/dir%2Ffile.txt",
+  "Records": [{
+    "eventVersion": "2.0",
+    "eventSource": "minio:s3",
+    "eventTime": "2024-01-01T00:00:00.000Z",
+    "eventName": "s3:ObjectCreated:Put",
+    "s3": {"bucket": {"name": "bucket"}, "object": {"key": "dir%2Ffile.txt", "size": 5}}
+  }]
+}`
+
+func TestParseEvent(t *testing.T) {
+	r := require.New(t)
+	event, err := ParseEvent([]byte(eventPayload))
+	r.NoError(err)
+	r.NoError(ValidateEvent(event))
+	r.Equal("dir/file.txt", event.Records[0].S3.Object.URLDecodedKey)
+
+	r.True(EventMatcher{EventS3ObjectCreated}.Match(event))
+	r.True(EventMatcher{EventS3ObjectRemoved, EventS3ObjectCreatedPut}.Match(event))
+	r.False(EventMatcher{EventS3ObjectRemoved}.Match(event))
+
+	event.Records = nil
+	r.Error(ValidateEvent(event))
+}
+
+func TestMatchEventName(t *testing.T) {
+	r := require.New(t)
+	r.True(MatchEventName(EventS3ObjectCreated, EventS3ObjectCreatedCopy))
+	r.True(MatchEventName(EventS3ObjectCreatedPut, EventS3ObjectCreatedPut))
+	r.False(MatchEventName(EventS3ObjectCreatedPut, EventS3ObjectCreatedPost))
+	r.False(MatchEventName(EventS3ObjectRemoved, EventS3ObjectCreatedPut))
+
+	records := []EventRecord{
+		{EventName: EventS3ObjectCreatedPut},
+		{EventName: EventS3ObjectRemovedDelete},
+		{EventName: EventS3ObjectCreatedCopy},
+	}
+	r.Len(FilterRecords(records, EventS3ObjectCreated), 2)
+}
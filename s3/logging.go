@@ -0,0 +1,90 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// LoggingS3 wraps an S3 and logs every operation with operation, bucket, key and duration,
+// successful operations are logged at DEBUG and failed ones at ERROR
+type LoggingS3 struct {
+	inner  S3
+	logger *slog.Logger
+}
+
+// NewLoggingS3 returns a LoggingS3 wrapping inner, slog.Default is used when logger is nil
+func NewLoggingS3(inner S3, logger *slog.Logger) S3 {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LoggingS3{inner: inner, logger: logger}
+}
+
+// log logs an operation started at start
+func (l *LoggingS3) log(ctx context.Context, operation, bucket, key string, start time.Time, err error) {
+	attrs := []slog.Attr{
+		slog.String("operation", operation),
+		slog.String("bucket", bucket),
+		slog.String("key", key),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+		l.logger.LogAttrs(ctx, slog.LevelError, "s3 operation failed", attrs...)
+		return
+	}
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "s3 operation", attrs...)
+}
+
+func (l *LoggingS3) PresignGetURL(ctx context.Context, bucket, key string, expire time.Duration,
+) (out *url.URL, err error) {
+	defer func(start time.Time) { l.log(ctx, "PresignGetURL", bucket, key, start, err) }(time.Now())
+	return l.inner.PresignGetURL(ctx, bucket, key, expire)
+}
+
+func (l *LoggingS3) PresignPutURL(ctx context.Context, bucket, key, contentType, sha256 string,
+	size int, expire time.Duration,
+) (out *url.URL, headers http.Header, err error) {
+	defer func(start time.Time) { l.log(ctx, "PresignPutURL", bucket, key, start, err) }(time.Now())
+	return l.inner.PresignPutURL(ctx, bucket, key, contentType, sha256, size, expire)
+}
+
+func (l *LoggingS3) GetObject(ctx context.Context, bucket, key string, opts minio.GetObjectOptions,
+) (out *minio.Object, err error) {
+	defer func(start time.Time) { l.log(ctx, "GetObject", bucket, key, start, err) }(time.Now())
+	return l.inner.GetObject(ctx, bucket, key, opts)
+}
+
+func (l *LoggingS3) StreamingGetObject(ctx context.Context, bucket, key string) (
+	out io.ReadCloser, size int64, err error,
+) {
+	defer func(start time.Time) { l.log(ctx, "StreamingGetObject", bucket, key, start, err) }(time.Now())
+	return l.inner.StreamingGetObject(ctx, bucket, key)
+}
+
+func (l *LoggingS3) PutObject(ctx context.Context, bucket, key, contentType string, size int,
+	body io.Reader, opts minio.PutObjectOptions,
+) (out minio.UploadInfo, err error) {
+	defer func(start time.Time) { l.log(ctx, "PutObject", bucket, key, start, err) }(time.Now())
+	return l.inner.PutObject(ctx, bucket, key, contentType, size, body, opts)
+}
+
+func (l *LoggingS3) CopyObject(ctx context.Context, bucket, srcKey, destKey string) (
+	out minio.UploadInfo, err error,
+) {
+	defer func(start time.Time) { l.log(ctx, "CopyObject", bucket, srcKey, start, err) }(time.Now())
+	return l.inner.CopyObject(ctx, bucket, srcKey, destKey)
+}
+
+func (l *LoggingS3) DeleteObject(ctx context.Context, bucket, key string) (err error) {
+	defer func(start time.Time) { l.log(ctx, "DeleteObject", bucket, key, start, err) }(time.Now())
+	return l.inner.DeleteObject(ctx, bucket, key)
+}
+
+var _ S3 = (*LoggingS3)(nil)
@@ -0,0 +1,37 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubS3 is an S3 whose DeleteObject returns err
+type stubS3 struct {
+	S3
+	err error
+}
+
+func (s *stubS3) DeleteObject(context.Context, string, string) error { return s.err }
+
+func TestLoggingS3(t *testing.T) {
+	r := require.New(t)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	stub := &stubS3{}
+	s3 := NewLoggingS3(stub, logger)
+	r.NoError(s3.DeleteObject(context.Background(), "bucket", "key"))
+	r.Contains(buf.String(), "level=DEBUG")
+	r.Contains(buf.String(), "operation=DeleteObject bucket=bucket key=key duration=")
+
+	buf.Reset()
+	stub.err = errors.New("access denied")
+	r.ErrorIs(s3.DeleteObject(context.Background(), "bucket", "key"), stub.err)
+	r.Contains(buf.String(), "level=ERROR")
+	r.Contains(buf.String(), "err=\"access denied\"")
+}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"fmt"
@@ -26,6 +27,8 @@ type S3 interface {
 	// GetObject gets an object from bucket
 	GetObject(ctx context.Context, bucket, key string, opt minio.GetObjectOptions) (
 		*minio.Object, error)
+	// StreamingGetObject returns the body of an object and its size, -1 if unknown
+	StreamingGetObject(ctx context.Context, bucket, key string) (io.ReadCloser, int64, error)
 	// PutObject uploads an object to bucket
 	PutObject(ctx context.Context, bucket, key, contentType string, size int,
 		body io.Reader, opts minio.PutObjectOptions) (minio.UploadInfo, error)
@@ -72,6 +75,26 @@ func (m *MinioS3Impl) GetObject(ctx context.Context, bucket, key string, opts mi
 	return
 }
 
+func (m *MinioS3Impl) StreamingGetObject(ctx context.Context, bucket, key string) (
+	io.ReadCloser, int64, error,
+) {
+	object, err := m.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get object: %w", err)
+	}
+	// minio-go does not send the request until the object is read or stat
+	info, err := object.Stat()
+	if err != nil {
+		_ = object.Close()
+		return nil, 0, fmt.Errorf("failed to stat object: %w", err)
+	}
+	size := info.Size
+	if size < 0 {
+		size = -1
+	}
+	return object, size, nil
+}
+
 func (m *MinioS3Impl) PutObject(ctx context.Context, bucket, key, contentType string,
 	size int, body io.Reader, opts minio.PutObjectOptions,
 ) (out minio.UploadInfo, err error) {
@@ -106,6 +129,29 @@ func (m *MinioS3Impl) DeleteObject(ctx context.Context, bucket, key string) erro
 	return nil
 }
 
+// ObjectExistsOrCreate creates an object with defaultContent only if it does not exist,
+// it is not atomic but the object is stat again when put fails for a concurrent creation.
+// it is not a part of S3 because it relies on stat object of minio
+func (m *MinioS3Impl) ObjectExistsOrCreate(ctx context.Context, bucket, key, contentType string,
+	defaultContent []byte,
+) (existed bool, err error) {
+	if _, err = m.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{}); err == nil {
+		return true, nil
+	} else if !IsNoSuchKeyErr(err) {
+		return false, fmt.Errorf("failed to stat object: %w", err)
+	}
+	opts := minio.PutObjectOptions{ContentType: contentType}
+	_, err = m.client.PutObject(ctx, bucket, key, bytes.NewReader(defaultContent),
+		int64(len(defaultContent)), opts)
+	if err == nil {
+		return false, nil
+	}
+	if _, statErr := m.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{}); statErr == nil {
+		return true, nil
+	}
+	return false, fmt.Errorf("failed to put object: %w", err)
+}
+
 // NewMinioS3Impl creates a new MinioS3Impl
 func NewMinioS3Impl(endpoint, accessKeyID, secretAccessKey, sessionToken string) (S3, error) {
 	return NewMinioS3ImplWithSTS(endpoint, &credentials.Static{
@@ -120,6 +166,14 @@ func NewMinioS3Impl(endpoint, accessKeyID, secretAccessKey, sessionToken string)
 
 // NewMinioS3ImplWithSTS creates a new MinioS3Impl with STSProvider
 func NewMinioS3ImplWithSTS(endpoint string, sts STSProvider) (S3, error) {
+	return NewMinioS3ImplWithCustomTransport(endpoint, sts, nil)
+}
+
+// NewMinioS3ImplWithCustomTransport creates a new MinioS3Impl with custom http transport,
+// e.g. a transport trusting internal CA certificates. nil transport uses the minio default.
+func NewMinioS3ImplWithCustomTransport(endpoint string, creds credentials.Provider,
+	transport http.RoundTripper,
+) (S3, error) {
 	uri, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
@@ -131,8 +185,9 @@ func NewMinioS3ImplWithSTS(endpoint string, sts STSProvider) (S3, error) {
 	endpoint = strings.TrimLeft(endpoint, "//")
 
 	opt := &minio.Options{
-		Creds:  credentials.New(sts),
-		Secure: isHttps,
+		Creds:     credentials.New(creds),
+		Secure:    isHttps,
+		Transport: transport,
 	}
 	c, err := minio.New(endpoint, opt)
 	if err != nil {
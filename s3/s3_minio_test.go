@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -174,3 +175,38 @@ func (s *TestMinioSuite) TestCopyObjectWithSameETag() {
 	r.NotNil(newObjectStat, "copied object stat is nil")
 	r.Equal(originStat.ETag, newObjectStat.ETag, "copied object ETag mismatch")
 }
+
+func (s *TestMinioSuite) TestObjectExistsOrCreate() {
+	r := s.Require()
+	ctx := context.Background()
+	impl, ok := s.s3.(*MinioS3Impl)
+	r.True(ok, "s3 should be a MinioS3Impl")
+	existed, err := impl.ObjectExistsOrCreate(ctx, s.bucket, ObjectKey, "text/plain", []byte("ignored"))
+	r.NoError(err, "failed to check existing object")
+	r.True(existed, "test object should exist")
+
+	key := ObjectKey + "-init"
+	defer func() { _ = s.s3.DeleteObject(ctx, s.bucket, key) }()
+	existed, err = impl.ObjectExistsOrCreate(ctx, s.bucket, key, "text/plain", []byte(ObjectBody))
+	r.NoError(err, "failed to create object")
+	r.False(existed, "object should be created")
+	existed, err = impl.ObjectExistsOrCreate(ctx, s.bucket, key, "text/plain", []byte("ignored"))
+	r.NoError(err, "failed to check created object")
+	r.True(existed, "created object should exist")
+}
+
+func (s *TestMinioSuite) TestStreamingGetObject() {
+	r := s.Require()
+	ctx := context.Background()
+	body, size, err := s.s3.StreamingGetObject(ctx, s.bucket, ObjectKey)
+	r.NoError(err, "failed to get object")
+	defer body.Close()
+	r.Equal(int64(len(ObjectBody)), size, "object size mismatch")
+	data, err := io.ReadAll(body)
+	r.NoError(err, "failed to read object")
+	r.Equal(ObjectBody, string(data), "object body mismatch")
+
+	_, _, err = s.s3.StreamingGetObject(ctx, s.bucket, ObjectKey+"-not-exist")
+	r.Error(err, "get object with certainly not exist key should fail")
+	r.True(IsNoSuchKeyErr(errors.Unwrap(err)), "get object with certainly not exist key should return not exists error")
+}
@@ -0,0 +1,68 @@
+package s3
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parseBaseURL parses base url, https scheme is assumed when scheme is absent
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+	uri, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base url: %w", err)
+	}
+	if uri.Host == "" {
+		return nil, fmt.Errorf("invalid base url: %q", baseURL)
+	}
+	return uri, nil
+}
+
+// escapeKey escapes each segment of the object key and keeps the slashes
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// ObjectURL returns the public url of an object in form of https://baseURL/bucket/key
+func ObjectURL(bucket, key, baseURL string) (string, error) {
+	if bucket == "" || key == "" {
+		return "", fmt.Errorf("bucket and key must not be empty")
+	}
+	uri, err := parseBaseURL(baseURL)
+	if err != nil {
+		return "", err
+	}
+	prefix := strings.TrimSuffix(uri.String(), "/")
+	return prefix + "/" + url.PathEscape(bucket) + "/" + escapeKey(strings.TrimPrefix(key, "/")), nil
+}
+
+// CDNPrefix rewrites an object url served by the s3 endpoint to be served by cdnDomain,
+// the path and query are kept. the input is returned unchanged if it can not be rewritten.
+func CDNPrefix(s3Endpoint, cdnDomain string) string {
+	if cdnDomain == "" {
+		return s3Endpoint
+	}
+	uri, err := url.Parse(s3Endpoint)
+	if err != nil || uri.Host == "" {
+		return s3Endpoint
+	}
+	cdn, err := parseBaseURL(cdnDomain)
+	if err != nil {
+		return s3Endpoint
+	}
+	uri.Scheme, uri.Host = cdn.Scheme, cdn.Host
+	if cdnPath := strings.TrimSuffix(cdn.Path, "/"); cdnPath != "" {
+		uri.Path = cdnPath + uri.Path
+		if uri.RawPath != "" {
+			uri.RawPath = cdnPath + uri.RawPath
+		}
+	}
+	return uri.String()
+}
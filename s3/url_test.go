@@ -0,0 +1,20 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectURL(t *testing.T) {
+	r := require.New(t)
+	out, err := ObjectURL("bucket", "dir/a b?.txt", "cdn.example.com")
+	r.NoError(err)
+	r.Equal("https://cdn.example.com/bucket/dir/a%20b%3F.txt", out)
+
+	_, err = ObjectURL("bucket", "key", "https://")
+	r.Error(err)
+
+	r.Equal("https://cdn.example.com/bucket/dir/a%20b.txt?x=1",
+		CDNPrefix("http://s3.example.com:9000/bucket/dir/a%20b.txt?x=1", "cdn.example.com"))
+}
@@ -0,0 +1,134 @@
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// HeaderEventSignature is the http header carrying the hex encoded HMAC-SHA256 signature of the body
+const HeaderEventSignature = "X-Event-Signature"
+
+// ErrInvalidEventSignature is returned when the webhook signature does not match the body
+var ErrInvalidEventSignature = errors.New("invalid event signature")
+
+// DefaultMaxEventBodySize is the default max size of the request body read by ParseEventFromRequest
+const DefaultMaxEventBodySize = 1 << 20
+
+// parseEventOptions that wraps the options of ParseEventFromRequest
+type parseEventOptions struct {
+	secret      []byte
+	maxBodySize int64
+}
+
+// ParseEventOption configures ParseEventFromRequest
+type ParseEventOption func(*parseEventOptions)
+
+// WithSecret enables HMAC-SHA256 signature verification with secret
+func WithSecret(secret string) ParseEventOption {
+	return func(o *parseEventOptions) {
+		o.secret = []byte(secret)
+	}
+}
+
+// WithMaxBodySize limits the request body to n bytes, n <= 0 means DefaultMaxEventBodySize
+func WithMaxBodySize(n int64) ParseEventOption {
+	return func(o *parseEventOptions) {
+		o.maxBodySize = n
+	}
+}
+
+// ParseEventFromRequest reads and closes the request body and parses an Event from it,
+// the signature header is verified when a secret is provided. a body larger than the max
+// body size is rejected with *http.MaxBytesError
+func ParseEventFromRequest(r *http.Request, opts ...ParseEventOption) (*Event, error) {
+	var o parseEventOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxBodySize <= 0 {
+		o.maxBodySize = DefaultMaxEventBodySize
+	}
+	if r.Body == nil {
+		return nil, errors.New("request body is empty")
+	}
+	defer func() { _ = r.Body.Close() }()
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, o.maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(o.secret) > 0 {
+		if err = verifySignature(o.secret, body, r.Header.Get(HeaderEventSignature)); err != nil {
+			return nil, err
+		}
+	}
+	return ParseEvent(body)
+}
+
+// verifySignature checks the hex encoded signature, an optional sha256= prefix is accepted
+func verifySignature(secret, body []byte, signature string) error {
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || len(got) == 0 {
+		return ErrInvalidEventSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidEventSignature
+	}
+	return nil
+}
+
+// EventHandlerFunc handles a parsed Event
+type EventHandlerFunc func(ctx context.Context, event *Event) error
+
+// eventRoute that wraps a registered pattern and its handler
+type eventRoute struct {
+	pattern EventName
+	fn      EventHandlerFunc
+}
+
+// EventRouter routes events to the handlers registered by event name pattern
+type EventRouter struct {
+	mu     sync.RWMutex
+	routes []eventRoute
+}
+
+// NewEventRouter returns an empty EventRouter
+func NewEventRouter() *EventRouter {
+	return &EventRouter{}
+}
+
+// RegisterHandler registers fn for events matching pattern, pattern supports * wildcard at the end only
+func (r *EventRouter) RegisterHandler(pattern EventName, fn EventHandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, eventRoute{pattern: pattern, fn: fn})
+}
+
+// Dispatch calls every handler whose pattern matches the event name in registration order,
+// it stops at the first handler error
+func (r *EventRouter) Dispatch(ctx context.Context, event *Event) error {
+	if event == nil {
+		return errors.New("event is nil")
+	}
+	r.mu.RLock()
+	routes := r.routes
+	r.mu.RUnlock()
+	for _, route := range routes {
+		if !MatchEventName(route.pattern, event.EventName) {
+			continue
+		}
+		if err := route.fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
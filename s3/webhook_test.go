@@ -0,0 +1,79 @@
+package s3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventFromRequest(t *testing.T) {
+	r := require.New(t)
+
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	event, err := ParseEventFromRequest(req)
+	r.NoError(err)
+	r.Equal(EventS3ObjectCreatedPut, event.EventName)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(eventPayload))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	req.Header.Set(HeaderEventSignature, "sha256="+signature)
+	_, err = ParseEventFromRequest(req, WithSecret("secret"))
+	r.NoError(err)
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	req.Header.Set(HeaderEventSignature, signature)
+	_, err = ParseEventFromRequest(req, WithSecret("other"))
+	r.ErrorIs(err, ErrInvalidEventSignature)
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	_, err = ParseEventFromRequest(req, WithSecret("secret"))
+	r.ErrorIs(err, ErrInvalidEventSignature)
+
+	var maxBytesErr *http.MaxBytesError
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	_, err = ParseEventFromRequest(req, WithMaxBodySize(int64(len(eventPayload)-1)))
+	r.ErrorAs(err, &maxBytesErr)
+
+	req = httptest.NewRequest("POST", "/webhook",
+		strings.NewReader(strings.Repeat(" ", DefaultMaxEventBodySize)+eventPayload))
+	_, err = ParseEventFromRequest(req)
+	r.ErrorAs(err, &maxBytesErr)
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(eventPayload))
+	_, err = ParseEventFromRequest(req, WithMaxBodySize(int64(len(eventPayload))))
+	r.NoError(err)
+}
+
+func TestEventRouter(t *testing.T) {
+	r := require.New(t)
+	event, err := ParseEvent([]byte(eventPayload))
+	r.NoError(err)
+
+	var called []string
+	router := NewEventRouter()
+	router.RegisterHandler(EventS3ObjectCreated, func(context.Context, *Event) error {
+		called = append(called, "created")
+		return nil
+	})
+	router.RegisterHandler(EventS3ObjectRemoved, func(context.Context, *Event) error {
+		called = append(called, "removed")
+		return nil
+	})
+	router.RegisterHandler(EventS3ObjectCreatedPut, func(context.Context, *Event) error {
+		called = append(called, "put")
+		return errors.New("failed")
+	})
+	r.EqualError(router.Dispatch(context.Background(), event), "failed")
+	r.Equal([]string{"created", "put"}, called)
+}
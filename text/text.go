@@ -1,19 +1,27 @@
 package text
 
 import (
+	"bytes"
 	crand "crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	mrand "math/rand"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
+	"golang.org/x/net/html"
 	"golang.org/x/text/width"
 )
 
@@ -40,6 +48,26 @@ func GeneratePassword(size int, accept func(byte) bool) (string, error) {
 	return string(password), nil
 }
 
+// GeneratePasswordWithEntropy generate password that carries at least entropyBits bits of entropy.
+// the charset size N is the number of bytes accepted by accept, each character contributes
+// log2(N) bits, so the password length L is the minimum value satisfying log2(N) * L >= entropyBits.
+func GeneratePasswordWithEntropy(entropyBits int, accept func(byte) bool) (string, error) {
+	if entropyBits <= 0 {
+		return "", fmt.Errorf("entropy bits must be positive: %d", entropyBits)
+	}
+	charsetSize := 0
+	for b := 0; b <= math.MaxUint8; b++ {
+		if accept(byte(b)) {
+			charsetSize++
+		}
+	}
+	if charsetSize < 2 {
+		return "", fmt.Errorf("charset size must be at least 2: %d", charsetSize)
+	}
+	size := int(math.Ceil(float64(entropyBits) / math.Log2(float64(charsetSize))))
+	return GeneratePassword(size, accept)
+}
+
 // GeneratePasswordLitterNumbers generate password with litter and numbers.
 func GeneratePasswordLitterNumbers(size int) (string, error) {
 	return GeneratePassword(size, func(b byte) bool {
@@ -55,6 +83,26 @@ func SaltSha256512(in, salt string) string {
 	return hex.EncodeToString(nrs[:])
 }
 
+// PasswordSaltSize is the size of random salt bytes used by HashPassword.
+const PasswordSaltSize = 16
+
+// HashPassword returns the salted hash of password and the generated salt.
+// the salt is 16 bytes from crypto/rand encoded as hex string.
+func HashPassword(password string) (hash, salt string, err error) {
+	buf := make([]byte, PasswordSaltSize)
+	if _, err = crand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("read random salt: %w", err)
+	}
+	salt = hex.EncodeToString(buf)
+	return SaltSha256512(password, salt), salt, nil
+}
+
+// VerifyPassword reports whether password matches the hash and salt returned by HashPassword.
+func VerifyPassword(password, hash, salt string) bool {
+	computed := SaltSha256512(password, salt)
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1
+}
+
 // RandString returns a random string with given length.
 func RandString(length int) string {
 	const charset = "ABCDEFGHIJKLMNPQRSTUVWXYZ0123456789"
@@ -89,6 +137,29 @@ func RandStringWithCharset(length int, charset string) string {
 	return string(b)
 }
 
+// RandStringSecure returns a random string with given length and charset.
+// every character is chosen by crypto/rand.Int which is free of modulo bias,
+// charset is treated as runes and has no length limit.
+func RandStringSecure(length int, charset string) (string, error) {
+	if length < 0 {
+		return "", fmt.Errorf("length must not be negative: %d", length)
+	}
+	runes := []rune(charset)
+	if len(runes) == 0 {
+		return "", fmt.Errorf("charset must not be empty")
+	}
+	size := big.NewInt(int64(len(runes)))
+	out := make([]rune, length)
+	for i := range out {
+		n, err := crand.Int(crand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		out[i] = runes[n.Int64()]
+	}
+	return string(out), nil
+}
+
 // CleanAllSpace returns a string with all space characters removed.
 func CleanAllSpace(s string) string {
 	return strings.Map(func(r rune) rune {
@@ -113,3 +184,407 @@ func CleanString(s string) string {
 func TrimString(s string) string {
 	return strings.TrimSpace(NarrowString(strings.ToValidUTF8(s, "")))
 }
+
+// isWordRune reports whether r can be part of a mention or hashtag token.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// extractTokens returns the deduplicated words following prefix in order of first appearance.
+// the prefix must not be preceded by a word rune, so "a@b" is not treated as a mention.
+func extractTokens(s string, prefix rune) (out []string) {
+	seen := make(map[string]struct{})
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != prefix || (i > 0 && isWordRune(runes[i-1])) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		if end == i+1 {
+			continue
+		}
+		token := string(runes[i+1 : end])
+		if _, ok := seen[token]; !ok {
+			seen[token] = struct{}{}
+			out = append(out, token)
+		}
+		i = end - 1
+	}
+	return
+}
+
+// ExtractMentions returns the deduplicated @username tokens in s without the @ prefix.
+func ExtractMentions(s string) []string {
+	return extractTokens(s, '@')
+}
+
+// ExtractHashtags returns the deduplicated #topic tokens in s without the # prefix.
+func ExtractHashtags(s string) []string {
+	return extractTokens(s, '#')
+}
+
+// commonInitialisms is the list of acronyms used to split consecutive upper case runs.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "QPS", "RAM", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS",
+	"TTL", "UDP", "UI", "UID", "URI", "URL", "UTF", "UUID", "VM", "XML", "XSRF", "XSS",
+}
+
+// splitInitialisms splits an upper case word into known initialisms,
+// it returns nil if the word can not be fully split.
+func splitInitialisms(word string) []string {
+	if word == "" {
+		return []string{}
+	}
+	for size := len(word); size > 0; size-- {
+		if !slices.Contains(commonInitialisms, word[:size]) {
+			continue
+		}
+		if rest := splitInitialisms(word[size:]); rest != nil {
+			return append([]string{word[:size]}, rest...)
+		}
+	}
+	return nil
+}
+
+// SplitCamelCase splits a camel case identifier into words.
+// consecutive upper case runs are treated as acronyms and split by common initialisms,
+// so "getHTTPSURL" returns ["get", "HTTPS", "URL"] and "HTTPServer" returns ["HTTP", "Server"].
+// non letter or digit characters are treated as separators and dropped.
+func SplitCamelCase(s string) (out []string) {
+	runes := []rune(s)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			word := string(runes[start:end])
+			if parts := splitInitialisms(word); len(parts) > 1 {
+				out = append(out, parts...)
+			} else {
+				out = append(out, word)
+			}
+		}
+		start = -1
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		switch {
+		case unicode.IsUpper(r) && !unicode.IsUpper(prev):
+			flush(i)
+			start = i
+		case unicode.IsLower(r) && unicode.IsUpper(prev) && i-1 > start:
+			flush(i - 1)
+			start = i - 1
+		}
+	}
+	flush(len(runes))
+	return
+}
+
+// ToWords returns the words of a camel case identifier joined with spaces.
+func ToWords(s string) string {
+	return strings.Join(SplitCamelCase(s), " ")
+}
+
+// SanitizeHTML strips html tags and returns the text content joined with spaces.
+// the content of script and style elements is dropped, malformed html is handled
+// by returning whatever text could be extracted.
+func SanitizeHTML(s string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	var (
+		texts   []string
+		skipped int
+	)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.Join(texts, " ")
+		case html.StartTagToken:
+			if name, _ := tokenizer.TagName(); isDroppedHTMLTag(name) {
+				skipped++
+			}
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); isDroppedHTMLTag(name) && skipped > 0 {
+				skipped--
+			}
+		case html.TextToken:
+			if skipped > 0 {
+				continue
+			}
+			if text := strings.TrimSpace(string(tokenizer.Text())); text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+}
+
+// isDroppedHTMLTag reports whether the content of the tag is dropped by SanitizeHTML.
+func isDroppedHTMLTag(name []byte) bool {
+	tag := string(name)
+	return tag == "script" || tag == "style"
+}
+
+// GenerateUUID returns a random RFC 4122 version 4 UUID using crypto/rand.
+func GenerateUUID() (string, error) {
+	var b [16]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("read random bytes: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// MustGenerateUUID returns a random version 4 UUID, it panics if crypto/rand fails.
+func MustGenerateUUID() string {
+	id, err := GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// IsPrintableASCII reports whether s is non-empty and all bytes are printable ASCII 0x20-0x7E.
+func IsPrintableASCII(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsOnlyDigits reports whether s is non-empty and all runes are unicode digits.
+func ContainsOnlyDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAlphanumeric reports whether s is non-empty and all runes are unicode letters or digits.
+func IsAlphanumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ssnMask is the fully masked social security number.
+const ssnMask = "***-**-****"
+
+// IsValidSSNFormat reports whether ssn is a US social security number formatted as
+// 123-45-6789 or 123456789.
+func IsValidSSNFormat(ssn string) bool {
+	switch len(ssn) {
+	case 9:
+	case 11:
+		if ssn[3] != '-' || ssn[6] != '-' {
+			return false
+		}
+		ssn = ssn[:3] + ssn[4:6] + ssn[7:]
+	default:
+		return false
+	}
+	for i := 0; i < len(ssn); i++ {
+		if ssn[i] < '0' || ssn[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MaskSSN masks a US social security number and keeps the last 4 digits as ***-**-6789.
+// invalid input is fully masked.
+func MaskSSN(ssn string) string {
+	if !IsValidSSNFormat(ssn) {
+		return ssnMask
+	}
+	return ssnMask[:len(ssnMask)-4] + ssn[len(ssn)-4:]
+}
+
+// durationUnits maps the human-readable duration units to durations.
+var durationUnits = map[string]time.Duration{
+	"ms": time.Millisecond, "msec": time.Millisecond, "msecs": time.Millisecond,
+	"millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour,
+	"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration string, it accepts the time.ParseDuration format and
+// human-readable forms such as "2 hours", "30 mins", "1 day" and "1 week 2 days".
+func ParseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total time.Duration
+	for i := 0; i < len(fields); i++ {
+		number, unit := fields[i], ""
+		if end := strings.IndexFunc(number, unicode.IsLetter); end > 0 {
+			number, unit = number[:end], number[end:]
+		} else if i+1 < len(fields) {
+			i++
+			unit = fields[i]
+		}
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid duration %q: invalid number %q", s, number)
+		}
+		scale, ok := durationUnits[unit]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", s, unit)
+		}
+		// float64(math.MaxInt64) rounds up to 2^63, so the bound itself already overflows
+		if math.IsNaN(value) || math.IsInf(value, 0) || value >= math.MaxInt64/float64(scale) {
+			return 0, fmt.Errorf("invalid duration %q: %q %s overflows", s, number, unit)
+		}
+		d := time.Duration(value * float64(scale))
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("invalid duration %q: overflows", s)
+		}
+		total += d
+	}
+	return total, nil
+}
+
+// jsonMask replaces the values of sensitive keys in ObfuscateJSON.
+const jsonMask = "***"
+
+// ObfuscateJSON replaces the values of sensitiveKeys in data with "***",
+// keys are matched case-insensitively in nested objects and arrays.
+// it returns an error only for invalid JSON, the output object keys are sorted.
+func ObfuscateJSON(data []byte, sensitiveKeys []string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid json: unexpected data after top-level value")
+	}
+	keys := make(map[string]struct{}, len(sensitiveKeys))
+	for _, key := range sensitiveKeys {
+		keys[strings.ToLower(key)] = struct{}{}
+	}
+	return json.Marshal(obfuscateJSONValue(value, keys))
+}
+
+// obfuscateJSONValue recursively masks the values of keys in value.
+func obfuscateJSONValue(value any, keys map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if _, ok := keys[strings.ToLower(key)]; ok {
+				v[key] = jsonMask
+				continue
+			}
+			v[key] = obfuscateJSONValue(item, keys)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = obfuscateJSONValue(item, keys)
+		}
+	}
+	return value
+}
+
+// CountWords returns the number of words in s separated by unicode spaces.
+func CountWords(s string) int {
+	return len(strings.Fields(s))
+}
+
+// sentenceAbbreviations are the lower case abbreviations whose period does not end a sentence.
+var sentenceAbbreviations = map[string]struct{}{
+	"mr": {}, "mrs": {}, "ms": {}, "dr": {}, "prof": {}, "sr": {}, "jr": {}, "st": {},
+	"vs": {}, "etc": {}, "e.g": {}, "i.e": {}, "no": {}, "inc": {}, "ltd": {}, "co": {},
+}
+
+// isSentenceTerminator reports whether r ends a sentence, full width punctuation is included.
+func isSentenceTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+// isAbbreviation reports whether the word at the end of runes is a known abbreviation.
+func isAbbreviation(runes []rune) bool {
+	start := len(runes)
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	word := strings.TrimLeftFunc(string(runes[start:]), unicode.IsPunct)
+	_, ok := sentenceAbbreviations[strings.ToLower(word)]
+	return ok
+}
+
+// CountSentences returns the number of sentences in s ended by '.', '!' or '?' and their
+// full width forms, repeated punctuation such as "?!" or "..." ends one sentence.
+// a period of an abbreviation such as "Mr." or inside a word such as "3.14" does not end
+// a sentence, and trailing text without punctuation is counted as a sentence.
+func CountSentences(s string) (n int) {
+	runes := []rune(s)
+	pending := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !isSentenceTerminator(r) {
+			pending = pending || !unicode.IsSpace(r)
+			continue
+		}
+		end := i
+		for end+1 < len(runes) && isSentenceTerminator(runes[end+1]) {
+			end++
+		}
+		if r == '.' && end == i {
+			if next := i + 1; next < len(runes) && !unicode.IsSpace(runes[next]) &&
+				!unicode.In(runes[next], unicode.Pe, unicode.Pf) && runes[next] != '"' && runes[next] != '\'' {
+				continue
+			}
+			if isAbbreviation(runes[:i]) {
+				continue
+			}
+		}
+		if pending {
+			n++
+			pending = false
+		}
+		i = end
+	}
+	if pending {
+		n++
+	}
+	return n
+}
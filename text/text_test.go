@@ -3,6 +3,7 @@ package text
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func removeAdjacentDuplicates(s string) string {
@@ -28,3 +29,212 @@ func TestRandString(t *testing.T) {
 		t.Fatal("RandString result unique adjacent chars is too short")
 	}
 }
+
+func TestRandStringSecure(t *testing.T) {
+	result, err := RandStringSecure(32, "ab中文")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runes := []rune(result); len(runes) != 32 {
+		t.Fatalf("expected 32 runes, got %d", len(runes))
+	}
+	if strings.Trim(result, "ab中文") != "" {
+		t.Fatalf("unexpected characters in %q", result)
+	}
+	if _, err = RandStringSecure(8, ""); err == nil {
+		t.Fatal("expected error for empty charset")
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, salt, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(salt) != PasswordSaltSize*2 {
+		t.Fatalf("expected salt length %d, got %d", PasswordSaltSize*2, len(salt))
+	}
+	if !VerifyPassword("secret", hash, salt) {
+		t.Fatal("VerifyPassword should accept the original password")
+	}
+	if VerifyPassword("other", hash, salt) {
+		t.Fatal("VerifyPassword should reject a different password")
+	}
+}
+
+func TestGeneratePasswordWithEntropy(t *testing.T) {
+	// 62 characters carry about 5.95 bits each, 128 bits requires 22 characters.
+	password, err := GeneratePasswordWithEntropy(128, func(b byte) bool {
+		return b >= '0' && b <= '9' || b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z'
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != 22 {
+		t.Fatalf("expected password length 22, got %d", len(password))
+	}
+	if _, err = GeneratePasswordWithEntropy(128, func(b byte) bool { return b == 'a' }); err == nil {
+		t.Fatal("expected error for single character charset")
+	}
+}
+
+func TestExtractMentionsAndHashtags(t *testing.T) {
+	s := "hi @alice and @bob_1, mail me at a@b.com @alice #go #中文 #go #"
+	mentions := ExtractMentions(s)
+	if strings.Join(mentions, ",") != "alice,bob_1" {
+		t.Fatalf("unexpected mentions: %v", mentions)
+	}
+	hashtags := ExtractHashtags(s)
+	if strings.Join(hashtags, ",") != "go,中文" {
+		t.Fatalf("unexpected hashtags: %v", hashtags)
+	}
+}
+
+func TestSplitCamelCase(t *testing.T) {
+	testCases := map[string]string{
+		"SecretKey":     "Secret Key",
+		"UserEmailHash": "User Email Hash",
+		"getHTTPSURL":   "get HTTPS URL",
+		"HTTPServer":    "HTTP Server",
+		"userID":        "user ID",
+		"UTF8String":    "UTF8 String",
+		"snake_case":    "snake case",
+		"":              "",
+	}
+	for input, want := range testCases {
+		if got := ToWords(input); got != want {
+			t.Fatalf("ToWords(%q) expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	testCases := map[string]string{
+		"<p>Hello <b>world</b></p>":                       "Hello world",
+		"<script>alert(1)</script>safe<style>p{}</style>": "safe",
+		"<div>broken <span>html":                          "broken html",
+		"&lt;escaped&gt; &amp; text":                      "<escaped> & text",
+		"":                                                "",
+	}
+	for input, want := range testCases {
+		if got := SanitizeHTML(input); got != want {
+			t.Fatalf("SanitizeHTML(%q) expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestGenerateUUID(t *testing.T) {
+	id := MustGenerateUUID()
+	if len(id) != 36 || id[14] != '4' || !strings.ContainsRune("89ab", rune(id[19])) {
+		t.Fatalf("invalid version 4 UUID: %s", id)
+	}
+	if id == MustGenerateUUID() {
+		t.Fatal("GenerateUUID should not repeat")
+	}
+}
+
+func TestInputValidators(t *testing.T) {
+	if !IsPrintableASCII("Hello, World~") || IsPrintableASCII("tab\t") || IsPrintableASCII("é") {
+		t.Fatal("unexpected IsPrintableASCII result")
+	}
+	if !ContainsOnlyDigits("0123") || ContainsOnlyDigits("12a") || ContainsOnlyDigits("") {
+		t.Fatal("unexpected ContainsOnlyDigits result")
+	}
+	if !IsAlphanumeric("abc123中文") || IsAlphanumeric("abc-123") || IsAlphanumeric("") {
+		t.Fatal("unexpected IsAlphanumeric result")
+	}
+}
+
+func TestMaskSSN(t *testing.T) {
+	tests := map[string]string{
+		"123-45-6789": "***-**-6789",
+		"123456789":   "***-**-6789",
+		"12345678":    "***-**-****",
+		"123-456-789": "***-**-****",
+		"12a-45-6789": "***-**-****",
+		"":            "***-**-****",
+	}
+	for in, want := range tests {
+		if got := MaskSSN(in); got != want {
+			t.Fatalf("MaskSSN(%q) = %q, want %q", in, got, want)
+		}
+		if valid := IsValidSSNFormat(in); valid != (want != ssnMask) {
+			t.Fatalf("IsValidSSNFormat(%q) = %v", in, valid)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"2h", 2 * time.Hour},
+		{"2 hours", 2 * time.Hour},
+		{"30 minutes", 30 * time.Minute},
+		{"30mins", 30 * time.Minute},
+		{"1 day", 24 * time.Hour},
+		{"1 Week 2 days", 9 * 24 * time.Hour},
+		{"1.5 hr", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil {
+			t.Fatalf("ParseDuration(%q) error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{
+		"", "2 fortnights", "hours", "-1 day", "2",
+		"nan hours", "inf hours", "100000000 weeks", "999999999999 days", "15000 weeks 15000 weeks",
+	} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Fatalf("ParseDuration(%q) expected error", in)
+		}
+	}
+}
+
+func TestObfuscateJSON(t *testing.T) {
+	data := []byte(`{"user":"alice","Password":"secret","id":12345678901234567890,` +
+		`"cards":[{"number":"4111","exp":"12/30"}],"meta":{"token":{"a":1}}}`)
+	out, err := ObfuscateJSON(data, []string{"password", "number", "token", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Password":"***","cards":[{"exp":"12/30","number":"***"}],` +
+		`"id":12345678901234567890,"meta":{"token":"***"},"user":"alice"}`
+	if string(out) != want {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if _, err = ObfuscateJSON([]byte(`{"a":`), nil); err == nil {
+		t.Fatal("expected error for invalid json")
+	}
+	if _, err = ObfuscateJSON([]byte(`{} {}`), nil); err == nil {
+		t.Fatal("expected error for trailing data")
+	}
+}
+
+func TestCountWordsAndSentences(t *testing.T) {
+	if n := CountWords("  hello,\tworld\n中文 词 "); n != 4 {
+		t.Fatalf("expected 4 words, got %d", n)
+	}
+	if n := CountWords(" \n\t"); n != 0 {
+		t.Fatalf("expected 0 words, got %d", n)
+	}
+	tests := map[string]int{
+		"":                                    0,
+		"Hello world":                         1,
+		"Mr. Smith met Dr. Who. They talked!": 2,
+		"Pi is 3.14. Really?! Yes...":         3,
+		"He said \"stop.\" Then left.":        2,
+		"你好。今天天气很好！是吗？":                     3,
+		"Bring fruit, e.g. apples. Thanks.": 2,
+	}
+	for in, want := range tests {
+		if got := CountSentences(in); got != want {
+			t.Fatalf("CountSentences(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package zap
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// envLevels are the log levels accepted by LOG_LEVEL.
+var envLevels = map[string]zapcore.Level{
+	"debug": zapcore.DebugLevel,
+	"info":  zapcore.InfoLevel,
+	"warn":  zapcore.WarnLevel,
+	"error": zapcore.ErrorLevel,
+}
+
+// envBool reads a boolean environment variable, ok is false when it is unset or invalid.
+func envBool(key string) (value, ok bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return false, false
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		zap.L().Warn("invalid boolean environment variable", zap.String("key", key),
+			zap.String("value", raw))
+		return false, false
+	}
+	return value, true
+}
+
+// envPositiveInt reads a positive integer environment variable, ok is false when it is unset or invalid.
+func envPositiveInt(key string) (value int, ok bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		zap.L().Warn("invalid integer environment variable", zap.String("key", key),
+			zap.String("value", raw))
+		return 0, false
+	}
+	return value, true
+}
+
+// NewZapFromEnv returns a zap logger configured by environment variables:
+//
+//   - LOG_LEVEL: debug, info, warn or error, defaults to debug.
+//   - LOG_FILE_PATH: the log file path, defaults to DefaultLogFilePath.
+//   - LOG_ROTATE: whether the log file is rotated by size, defaults to false.
+//   - LOG_MAX_SIZE_MB: the maximum log file size in megabytes before rotation.
+//   - LOG_MAX_AGE_DAYS: the maximum number of days to retain rotated log files.
+//   - LOG_CONSOLE: whether Slog writes all levels to console, false disables console output,
+//     defaults to writing warnings and above only.
+//
+// invalid values are reported through the global zap logger, which is a no-op logger
+// unless replaced, and the defaults are used.
+func NewZapFromEnv() (*Zap, func(), error) {
+	level := zapcore.DebugLevel
+	if raw := os.Getenv("LOG_LEVEL"); raw != "" {
+		if parsed, ok := envLevels[strings.ToLower(raw)]; ok {
+			level = parsed
+		} else {
+			zap.L().Warn("invalid LOG_LEVEL environment variable", zap.String("value", raw))
+		}
+	}
+
+	path := os.Getenv("LOG_FILE_PATH")
+	if path == "" {
+		var err error
+		if path, err = DefaultLogFilePath(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var (
+		z       *Zap
+		cleanup func()
+		err     error
+	)
+	if rotate, _ := envBool("LOG_ROTATE"); rotate {
+		var opts []RotationOption
+		if size, ok := envPositiveInt("LOG_MAX_SIZE_MB"); ok {
+			opts = append(opts, WithMaxSize(size))
+		}
+		if age, ok := envPositiveInt("LOG_MAX_AGE_DAYS"); ok {
+			opts = append(opts, WithMaxAge(age))
+		}
+		z, cleanup, err = newZapWithRotationFile(path, opts...)
+	} else {
+		z, cleanup, err = newZapWithFile(path)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	z.level = level
+	if console, ok := envBool("LOG_CONSOLE"); ok {
+		z.consoleLevel = consoleDisabledLevel
+		if console {
+			z.consoleLevel = level
+		}
+	}
+	return z, cleanup, nil
+}
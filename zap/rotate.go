@@ -0,0 +1,53 @@
+package zap
+
+import (
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// dailyRotateDateLayout is the date layout compared by dailyRotateWriter.
+const dailyRotateDateLayout = "2006-01-02"
+
+// dailyRotateWriter rotates the lumberjack log file when the UTC date changes.
+type dailyRotateWriter struct {
+	mu          sync.Mutex
+	logger      *lumberjack.Logger
+	now         func() time.Time
+	currentDate string
+}
+
+// newDailyRotateWriter returns a dailyRotateWriter of logger.
+func newDailyRotateWriter(logger *lumberjack.Logger) *dailyRotateWriter {
+	return newDailyRotateWriterWithClock(logger, time.Now)
+}
+
+// newDailyRotateWriterWithClock returns a dailyRotateWriter of logger using now as clock.
+func newDailyRotateWriterWithClock(logger *lumberjack.Logger, now func() time.Time) *dailyRotateWriter {
+	return &dailyRotateWriter{
+		logger:      logger,
+		now:         now,
+		currentDate: now().UTC().Format(dailyRotateDateLayout),
+	}
+}
+
+// Write rotates the log file first when the UTC date has changed since the last write.
+func (w *dailyRotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if date := w.now().UTC().Format(dailyRotateDateLayout); date != w.currentDate {
+		if err := w.logger.Rotate(); err != nil {
+			return 0, err
+		}
+		w.currentDate = date
+	}
+	return w.logger.Write(p)
+}
+
+// Close closes the log file.
+func (w *dailyRotateWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.logger.Close()
+}
@@ -2,6 +2,7 @@ package zap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,6 +12,10 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/exp/zapslog"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/term"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	kiterrors "github.com/crypto-zero/go-kit/errors"
 )
 
 const (
@@ -68,7 +73,36 @@ func (f FunctionField) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	return nil
 }
 
-var _ zapcore.ObjectMarshaler = FunctionField{}
+// LogValue implements slog.LogValuer, it evaluates F with a background context.
+// WrapHandler resolves FunctionField with the record context before it reaches here.
+func (f FunctionField) LogValue() slog.Value {
+	return slog.AnyValue(f.F(context.Background()))
+}
+
+var (
+	_ zapcore.ObjectMarshaler = FunctionField{}
+	_ slog.LogValuer          = FunctionField{}
+)
+
+// KratosError returns a structured error field with code, reason, message and domain
+// when err is a kit *errors.Error, otherwise it returns zap.Error(err).
+func KratosError(err error) zap.Field {
+	var se *kiterrors.Error
+	if !errors.As(err, &se) || se == nil {
+		return zap.Error(err)
+	}
+	return zap.Object("error", zapcore.ObjectMarshalerFunc(
+		func(encoder zapcore.ObjectEncoder) error {
+			encoder.AddInt32("code", se.Status)
+			encoder.AddString("message", se.Message)
+			if se.Info != nil {
+				encoder.AddString("reason", se.Info.Reason)
+				encoder.AddString("domain", se.Info.Domain)
+			}
+			return nil
+		},
+	))
+}
 
 // WrapHandler wraps a slog handler.
 type WrapHandler struct {
@@ -78,7 +112,9 @@ type WrapHandler struct {
 	dynamicAttrs []slog.Attr
 }
 
-func (h *WrapHandler) clone(newHandler slog.Handler) *WrapHandler {
+// Clone returns a copy of the handler that wraps newHandler,
+// the dynamic attributes are copied so the handlers never share them.
+func (h *WrapHandler) Clone(newHandler slog.Handler) *WrapHandler {
 	return &WrapHandler{
 		name:         h.name,
 		core:         h.core,
@@ -92,7 +128,7 @@ func (h *WrapHandler) resolveAttrs(ctx context.Context, attrs []slog.Attr) (
 ) {
 	out = make([]slog.Attr, 0, len(attrs))
 	for _, attr := range attrs {
-		if attr.Value.Kind() != slog.KindAny {
+		if kind := attr.Value.Kind(); kind != slog.KindAny && kind != slog.KindLogValuer {
 			out = append(out, attr)
 			continue
 		}
@@ -122,28 +158,37 @@ func (h *WrapHandler) resolveNameFromAttrs(attrs []slog.Attr) slog.Handler {
 		name = fmt.Sprintf("%s.%s", h.name, name)
 	}
 	handler := zapslog.NewHandler(h.core, zapslog.WithName(name))
-	wrap := h.clone(handler)
+	wrap := h.Clone(handler)
 	wrap.name = name
 	return wrap
 }
 
-func (h *WrapHandler) filterDynamicAttrs(attrs []slog.Attr) (out []slog.Attr) {
-	out = make([]slog.Attr, 0, len(attrs))
+// splitDynamicAttrs splits attrs into static attrs and dynamic attrs of Valuer values,
+// it never modifies the handler.
+func splitDynamicAttrs(attrs []slog.Attr) (static, dynamic []slog.Attr) {
+	static = make([]slog.Attr, 0, len(attrs))
 	for _, attr := range attrs {
-		if attr.Value.Kind() != slog.KindAny {
-			out = append(out, attr)
-			continue
-		}
-		value := attr.Value.Any()
-		f, ok := value.(Valuer)
-		if ok {
-			attr = slog.Any(attr.Key, FunctionField{Key: attr.Key, F: f})
-			h.dynamicAttrs = append(h.dynamicAttrs, attr)
+		if attr.Value.Kind() == slog.KindAny {
+			if f, ok := attr.Value.Any().(Valuer); ok {
+				dynamic = append(dynamic, slog.Any(attr.Key, FunctionField{Key: attr.Key, F: f}))
+				continue
+			}
 		}
+		static = append(static, attr)
 	}
 	return
 }
 
+// WithDynamicFields returns a handler with fields that are always resolved from the
+// context passed to Handle, it is an explicit alternative to slog.Any(key, Valuer).
+func (h *WrapHandler) WithDynamicFields(fields ...FunctionField) *WrapHandler {
+	wrap := h.Clone(h.handler)
+	for _, field := range fields {
+		wrap.dynamicAttrs = append(wrap.dynamicAttrs, slog.Any(field.Key, field))
+	}
+	return wrap
+}
+
 func (h *WrapHandler) Enabled(ctx context.Context, level slog.Level) bool {
 	return h.handler.Enabled(ctx, level)
 }
@@ -170,14 +215,21 @@ func (h *WrapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	if newHandler := h.resolveNameFromAttrs(attrs); newHandler != nil {
 		return newHandler
 	}
-	if newAttrs := h.filterDynamicAttrs(attrs); len(newAttrs) > 0 {
-		return h.clone(h.handler.WithAttrs(newAttrs))
+	static, dynamic := splitDynamicAttrs(attrs)
+	if len(static) == 0 && len(dynamic) == 0 {
+		return h
+	}
+	handler := h.handler
+	if len(static) > 0 {
+		handler = handler.WithAttrs(static)
 	}
-	return h
+	wrap := h.Clone(handler)
+	wrap.dynamicAttrs = append(wrap.dynamicAttrs, dynamic...)
+	return wrap
 }
 
 func (h *WrapHandler) WithGroup(name string) slog.Handler {
-	return h.clone(h.handler.WithGroup(name))
+	return h.Clone(h.handler.WithGroup(name))
 }
 
 // WithFields adds fields to the zap logger.
@@ -189,10 +241,22 @@ func WithFields[T comparable](z *Zap, fields map[string]T) {
 
 var _ slog.Handler = (*WrapHandler)(nil)
 
+// consoleDisabledLevel is the console level that disables console output of Slog.
+const consoleDisabledLevel = zapcore.InvalidLevel
+
 // Zap is a logger.
 type Zap struct {
 	kvs    []any
 	writer zapcore.WriteSyncer
+	// level is the minimum level of Logger and Slog.
+	level zapcore.Level
+	// consoleLevel is the minimum console level of Slog.
+	consoleLevel zapcore.Level
+}
+
+// newZapWithWriter returns a zap logger with default levels.
+func newZapWithWriter(writer zapcore.WriteSyncer) *Zap {
+	return &Zap{writer: writer, level: zapcore.DebugLevel, consoleLevel: zapcore.WarnLevel}
 }
 
 func (z *Zap) zapFields() (out []zap.Field) {
@@ -238,6 +302,20 @@ func (z *Zap) NewConsoleCore(config zapcore.EncoderConfig, level zapcore.Level)
 	)
 }
 
+// NewConsoleCoreWithColor returns a zap console core with human-readable output for development.
+// levels are colorized only when stdout is a terminal, it should not be used in production.
+func (z *Zap) NewConsoleCoreWithColor(config zapcore.EncoderConfig, level zapcore.Level) zapcore.Core {
+	config.EncodeLevel = zapcore.CapitalLevelEncoder
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		config.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+	return zapcore.NewCore(
+		zapcore.NewConsoleEncoder(config),
+		os.Stdout,
+		level,
+	)
+}
+
 // NewMixedConsoleCore returns a zap mixed console core.
 func (z *Zap) NewMixedConsoleCore(config zapcore.EncoderConfig, fileLevel,
 	consoleLevel zapcore.Level,
@@ -255,7 +333,7 @@ func (z *Zap) LoggerWithCore(core zapcore.Core) *zap.Logger {
 
 // Logger returns a zap logger.
 func (z *Zap) Logger() *zap.Logger {
-	core := z.NewCore(z.NewEncoderConfig(), zapcore.DebugLevel)
+	core := z.NewCore(z.NewEncoderConfig(), z.level)
 	return z.LoggerWithCore(core)
 }
 
@@ -270,24 +348,111 @@ func (z *Zap) SlogWithCore(core zapcore.Core) *slog.Logger {
 	return slog.New(wrap).With(z.kvs...)
 }
 
+// ToSlogHandler returns the *WrapHandler of a logger created by SlogWithCore,
+// ok is false when the logger handler is not a *WrapHandler.
+func ToSlogHandler(logger *slog.Logger) (handler slog.Handler, ok bool) {
+	if logger == nil {
+		return nil, false
+	}
+	wrap, ok := logger.Handler().(*WrapHandler)
+	if !ok {
+		return nil, false
+	}
+	return wrap, true
+}
+
 // Slog returns a slog logger.
 func (z *Zap) Slog() *slog.Logger {
-	core := z.NewMixedConsoleCore(z.NewEncoderConfig(), zapcore.DebugLevel, zapcore.WarnLevel)
+	if z.consoleLevel == consoleDisabledLevel {
+		return z.SlogWithCore(z.NewCore(z.NewEncoderConfig(), z.level))
+	}
+	core := z.NewMixedConsoleCore(z.NewEncoderConfig(), z.level, z.consoleLevel)
 	return z.SlogWithCore(core)
 }
 
+// DefaultLogFilePath returns the log file path logs/<program>.log in the working directory.
+func DefaultLogFilePath() (string, error) {
+	name := fmt.Sprintf("%s.log", filepath.Base(os.Args[0]))
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("get working directory: %w", err)
+	}
+	return filepath.Join(wd, "logs", name), nil
+}
+
 // NewZap returns a zap logger.
 // zapslog stabilization tracking issue: https://github.com/uber-go/zap/issues/1333
 func NewZap() (*Zap, func(), error) {
-	name := fmt.Sprintf("%s.log", filepath.Base(os.Args[0]))
-	wd, err := os.Getwd()
+	path, err := DefaultLogFilePath()
 	if err != nil {
-		return nil, nil, fmt.Errorf("get working directory: %w", err)
+		return nil, nil, err
 	}
+	return newZapWithFile(path)
+}
 
-	writer, cleanup, err := zap.Open(filepath.Join(wd, "logs", name))
+// newZapWithFile returns a zap logger that writes to the log file path.
+func newZapWithFile(path string) (*Zap, func(), error) {
+	writer, cleanup, err := zap.Open(path)
 	if err != nil {
 		return nil, nil, fmt.Errorf("open log file: %w", err)
 	}
-	return &Zap{writer: writer}, cleanup, nil
+	return newZapWithWriter(writer), cleanup, nil
+}
+
+// rotationConfig holds the rotated log file config of NewZapWithRotation.
+type rotationConfig struct {
+	logger *lumberjack.Logger
+	daily  bool
+}
+
+// RotationOption configures the rotated log file of NewZapWithRotation.
+type RotationOption func(c *rotationConfig)
+
+// WithMaxSize sets the maximum size in megabytes of the log file before it gets rotated.
+func WithMaxSize(megabytes int) RotationOption {
+	return func(c *rotationConfig) { c.logger.MaxSize = megabytes }
+}
+
+// WithMaxAge sets the maximum number of days to retain rotated log files.
+func WithMaxAge(days int) RotationOption {
+	return func(c *rotationConfig) { c.logger.MaxAge = days }
+}
+
+// WithMaxBackups sets the maximum number of rotated log files to retain.
+func WithMaxBackups(backups int) RotationOption {
+	return func(c *rotationConfig) { c.logger.MaxBackups = backups }
+}
+
+// WithCompression sets whether rotated log files are compressed using gzip.
+// compression reduces disk usage significantly but costs CPU on every rotation.
+func WithCompression(compress bool) RotationOption {
+	return func(c *rotationConfig) { c.logger.Compress = compress }
+}
+
+// WithDailyRotation sets whether the log file is also rotated at midnight in UTC.
+func WithDailyRotation(daily bool) RotationOption {
+	return func(c *rotationConfig) { c.daily = daily }
+}
+
+// NewZapWithRotation returns a zap logger that writes to a size rotated log file,
+// use WithDailyRotation to rotate it at midnight in UTC as well.
+func NewZapWithRotation(opts ...RotationOption) (*Zap, func(), error) {
+	path, err := DefaultLogFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	return newZapWithRotationFile(path, opts...)
+}
+
+// newZapWithRotationFile returns a zap logger that writes to the size rotated log file path.
+func newZapWithRotationFile(path string, opts ...RotationOption) (*Zap, func(), error) {
+	c := &rotationConfig{logger: &lumberjack.Logger{Filename: path}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.daily {
+		writer := newDailyRotateWriter(c.logger)
+		return newZapWithWriter(zapcore.AddSync(writer)), func() { _ = writer.Close() }, nil
+	}
+	return newZapWithWriter(zapcore.AddSync(c.logger)), func() { _ = c.logger.Close() }, nil
 }
@@ -2,9 +2,19 @@ package zap
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
 
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	kiterrors "github.com/crypto-zero/go-kit/errors"
 )
 
 func testValuer(ctx context.Context) any {
@@ -53,3 +63,214 @@ func ExampleZap() {
 	// {"level":"WARN","msg":"a warning"}
 	// {"level":"WARN","msg":"a warning"}
 }
+
+func ExampleWrapHandler_WithDynamicFields() {
+	z := &Zap{writer: os.Stdout}
+
+	cfg := z.NewEncoderConfig()
+	cfg.TimeKey = ""
+
+	logger := z.SlogWithCore(z.NewCore(cfg, zapcore.DebugLevel))
+	handler := logger.Handler().(*WrapHandler)
+	logger = slog.New(handler.WithDynamicFields(FunctionField{Key: "valuer", F: testValuer}))
+	logger.Info("dynamic")
+
+	// Output:
+	// {"level":"INFO","msg":"dynamic","valuer":"test-valuer"}
+}
+
+func TestNewZapFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	t.Setenv("LOG_FILE_PATH", path)
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_CONSOLE", "false")
+	t.Setenv("LOG_ROTATE", "invalid")
+
+	z, cleanup, err := NewZapFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := z.Slog()
+	logger.Info("dropped")
+	logger.Warn("kept")
+	cleanup()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "dropped") || !strings.Contains(string(data), "kept") {
+		t.Fatalf("unexpected log file content: %s", data)
+	}
+}
+
+func ExampleKratosError() {
+	z := &Zap{writer: os.Stdout}
+
+	cfg := z.NewEncoderConfig()
+	cfg.TimeKey = ""
+
+	logger := z.LoggerWithCore(z.NewCore(cfg, zapcore.DebugLevel))
+	err := kiterrors.NotFound("USER_NOT_FOUND", "user not found")
+	logger.Error("structured", KratosError(fmt.Errorf("wrapped: %w", err)))
+	logger.Error("plain", KratosError(errors.New("plain error")))
+
+	// Output:
+	// {"level":"ERROR","msg":"structured","error":{"code":404,"message":"user not found","reason":"USER_NOT_FOUND","domain":""}}
+	// {"level":"ERROR","msg":"plain","error":"plain error"}
+}
+
+func TestWrapHandler_Clone(t *testing.T) {
+	z := &Zap{writer: os.Stdout}
+	handler := z.SlogWithCore(z.NewCore(z.NewEncoderConfig(), zapcore.DebugLevel)).Handler().(*WrapHandler)
+	handler = handler.WithDynamicFields(FunctionField{Key: "first", F: testValuer})
+
+	cloned := handler.Clone(handler.handler)
+	cloned.dynamicAttrs[0] = slog.String("first", "changed")
+	if _, ok := handler.dynamicAttrs[0].Value.Any().(FunctionField); !ok {
+		t.Fatal("parent dynamic attr was modified through the clone")
+	}
+
+	child := handler.WithAttrs([]slog.Attr{slog.Any("second", Valuer(testValuer))}).(*WrapHandler)
+	if child == handler {
+		t.Fatal("WithAttrs with a Valuer must return a new handler")
+	}
+	if len(handler.dynamicAttrs) != 1 {
+		t.Fatalf("expected parent handler to keep 1 dynamic attr, got %d", len(handler.dynamicAttrs))
+	}
+	if len(child.dynamicAttrs) != 2 {
+		t.Fatalf("expected child handler to have 2 dynamic attrs, got %d", len(child.dynamicAttrs))
+	}
+}
+
+func TestFunctionField_LogValue(t *testing.T) {
+	type ctxKey struct{}
+	calls := 0
+	field := FunctionField{Key: "user", F: func(ctx context.Context) any {
+		calls++
+		if v, ok := ctx.Value(ctxKey{}).(string); ok {
+			return v
+		}
+		return "background"
+	}}
+
+	if got := field.LogValue().String(); got != "background" {
+		t.Fatalf("expected background, got %s", got)
+	}
+
+	var buf strings.Builder
+	z := &Zap{writer: zapcore.AddSync(&buf)}
+	cfg := z.NewEncoderConfig()
+	cfg.TimeKey = ""
+	logger := z.SlogWithCore(z.NewCore(cfg, zapcore.DebugLevel))
+
+	calls = 0
+	ctx := context.WithValue(context.Background(), ctxKey{}, "alice")
+	logger.InfoContext(ctx, "hello", slog.Any(field.Key, field))
+	if calls != 1 {
+		t.Fatalf("expected F to be evaluated once, got %d", calls)
+	}
+	if !strings.Contains(buf.String(), `"user":"alice"`) {
+		t.Fatalf("expected value resolved from record context, got %s", buf.String())
+	}
+}
+
+func TestDailyRotateWriter_RotatesAtMidnight(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	logger := &lumberjack.Logger{Filename: path}
+	defer func() { _ = logger.Close() }()
+
+	now := time.Date(2024, 1, 1, 23, 59, 59, 999999999, time.UTC)
+	writer := newDailyRotateWriterWithClock(logger, func() time.Time { return now })
+
+	if _, err := writer.Write([]byte("before midnight\n")); err != nil {
+		t.Fatal(err)
+	}
+	now = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := writer.Write([]byte("at midnight\n")); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Hour)
+	if _, err := writer.Write([]byte("after midnight\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the log file and one rotated backup, got %d files", len(entries))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "at midnight\nafter midnight\n" {
+		t.Fatalf("unexpected log file content: %q", data)
+	}
+}
+
+func TestRotationOptions(t *testing.T) {
+	c := &rotationConfig{logger: &lumberjack.Logger{}}
+	for _, opt := range []RotationOption{
+		WithMaxSize(10), WithMaxAge(7), WithMaxBackups(3), WithCompression(true), WithDailyRotation(true),
+	} {
+		opt(c)
+	}
+	if c.logger.MaxSize != 10 || c.logger.MaxAge != 7 || c.logger.MaxBackups != 3 {
+		t.Fatalf("unexpected rotation limits: %+v", c.logger)
+	}
+	if !c.logger.Compress || !c.daily {
+		t.Fatalf("expected compression and daily rotation, got %+v %v", c.logger, c.daily)
+	}
+}
+
+func TestNewZapWithRotation(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	for _, daily := range []bool{false, true} {
+		z, cleanup, err := NewZapWithRotation(WithMaxSize(1), WithCompression(true), WithDailyRotation(daily))
+		if err != nil {
+			t.Fatal(err)
+		}
+		z.Slog().Info("rotated", "daily", daily)
+		cleanup()
+	}
+
+	path, err := DefaultLogFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"daily":false`) || !strings.Contains(string(data), `"daily":true`) {
+		t.Fatalf("unexpected log file content: %s", data)
+	}
+}
+
+func TestToSlogHandler(t *testing.T) {
+	z := &Zap{writer: os.Stdout}
+	logger := z.SlogWithCore(z.NewCore(z.NewEncoderConfig(), zapcore.DebugLevel)).With("valuer", Valuer(testValuer))
+	handler, ok := ToSlogHandler(logger)
+	if !ok {
+		t.Fatal("expected a WrapHandler")
+	}
+	if wrap := handler.(*WrapHandler); len(wrap.dynamicAttrs) != 1 {
+		t.Fatalf("expected 1 dynamic attr, got %d", len(wrap.dynamicAttrs))
+	}
+	if _, ok = ToSlogHandler(slog.Default()); ok {
+		t.Fatal("expected default logger not to have a WrapHandler")
+	}
+}